@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"time"
 )
 
 // HTTPClient is an interface for making HTTP requests.
@@ -25,11 +28,97 @@ const (
 	userToken     = "X-USER-TOKEN"
 )
 
+// requester sends API requests with the settings of a Client.
+// It is shared by the sub-clients built from the Client.
+type requester struct {
+	httpClient     HTTPClient
+	retryPolicy    *RetryPolicy
+	errorOnFailure bool
+	rateLimiter    *RateLimiter
+	middlewares    []Middleware
+	logger         *slog.Logger
+	logLevels      *LogLevels
+	tracer         Tracer
+}
+
+// do sends the request, retrying it according to the retry policy.
+// If the request is still rejected after all attempts, it returns an *APIError matching ErrAPICallRejected.
+func (r *requester) do(ctx context.Context, param *requestParameter) (*retryer, error) {
+	policy := r.retryPolicy
+	if policy == nil {
+		policy = legacyRetryPolicy()
+	}
+	retry := &retryer{
+		processFunc: r.processFunc(param),
+		policy:      policy,
+		method:      param.Method,
+	}
+	retry.startAttempt = r.startAttempt(param, retry)
+	if err := retry.do(ctx); err != nil {
+		if errors.Is(err, ErrAPICallRejected) {
+			return retry, newAPIError(param, retry.statusCode, retry.body)
+		}
+		return retry, err
+	}
+
+	return retry, nil
+}
+
+// startAttempt returns the function called before each attempt of the request to log and trace it,
+// or nil if the client has neither a logger nor a Tracer.
+func (r *requester) startAttempt(param *requestParameter, m *retryer) func(ctx context.Context, attempt int) (context.Context, func(retryable bool)) {
+	if r.logger == nil && r.tracer == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, attempt int) (context.Context, func(retryable bool)) {
+		start := time.Now()
+		endSpan := func(AttemptResult) {}
+		if r.tracer != nil {
+			ctx, endSpan = r.tracer.StartAttempt(ctx, Attempt{Operation: param.operation(), Number: attempt + 1})
+		}
+		return ctx, func(retryable bool) {
+			if r.logger != nil {
+				r.logAttempt(ctx, param, m, attempt, time.Since(start), retryable)
+			}
+			result := AttemptResult{Err: m.err, Retry: retryable}
+			if m.err == nil {
+				result.StatusCode = m.statusCode
+				result.IsRejected = m.rejected
+			}
+			endSpan(result)
+		}
+	}
+}
+
+// startOperation starts tracing the API operation of the request if the client has a Tracer.
+// The returned function ends it with the retryer of the request and the error returned to the caller.
+func (r *requester) startOperation(ctx context.Context, param *requestParameter) (context.Context, func(m *retryer, err error)) {
+	if r.tracer == nil {
+		return ctx, func(*retryer, error) {}
+	}
+
+	ctx, end := r.tracer.StartOperation(ctx, param.operation())
+	return ctx, func(m *retryer, err error) {
+		result := OperationResult{Attempts: m.attempts, Err: err}
+		if m.err == nil {
+			result.StatusCode = m.statusCode
+		}
+		end(result)
+	}
+}
+
 type requestParameter struct {
-	Method string
-	URL    string
-	Header map[string]string
-	Body   []byte
+	// Operation is the name of the API operation such as "pixela.Graph.Stats".
+	Operation string
+	Method    string
+	URL       string
+	Header    map[string]string
+	Body      []byte
+}
+
+func (p *requestParameter) operation() Operation {
+	return Operation{Name: p.Operation, Method: p.Method, URL: redactURL(p.URL)}
 }
 
 // Result is Pixela API Result struct.
@@ -59,33 +148,48 @@ func newHTTPRequest(ctx context.Context, param *requestParameter) (*http.Request
 	return req, nil
 }
 
-func doRequest(ctx context.Context, httpClient HTTPClient, param *requestParameter) ([]byte, int, error) {
-	retry := retryer{
-		processFunc: processFunc(ctx, httpClient, param),
-		maxRetry:    getRetryCount(),
-	}
-	if err := retry.do(ctx); err != nil {
+func doRequest(ctx context.Context, r *requester, param *requestParameter) (_ []byte, _ int, err error) {
+	ctx, end := r.startOperation(ctx, param)
+	retry, err := r.do(ctx, param)
+	defer func() { end(retry, err) }()
+	if err != nil {
 		return []byte{}, 0, err
 	}
 
+	if r.errorOnFailure && retry.statusCode >= 300 {
+		return []byte{}, 0, newAPIError(param, retry.statusCode, retry.body)
+	}
+
 	return retry.body, retry.statusCode, nil
 }
 
-func processFunc(ctx context.Context, httpClient HTTPClient, param *requestParameter) func(m *retryer) {
-	return func(m *retryer) {
+// processFunc returns the function that makes an attempt of the request.
+// ctx is the context of the attempt.
+func (r *requester) processFunc(param *requestParameter) func(ctx context.Context, m *retryer) {
+	doer := chain(r.httpClient, r.middlewares)
+	return func(ctx context.Context, m *retryer) {
+		m.header = nil
+		if r.rateLimiter != nil {
+			if err := r.rateLimiter.Wait(ctx); err != nil {
+				m.err = fmt.Errorf("failed to wait for rate limiter: %w", err)
+				return
+			}
+		}
+
 		req, err := newHTTPRequest(ctx, param)
 		if err != nil {
 			m.err = fmt.Errorf("failed to create http.Request: %w", err)
 			return
 		}
 
-		resp, err := httpClient.Do(req)
+		resp, err := doer.Do(req)
 		if err != nil {
 			m.err = fmt.Errorf("failed http.Client do: %w", err)
 			return
 		}
 		defer resp.Body.Close()
 
+		m.header = resp.Header
 		b, err := io.ReadAll(resp.Body)
 		if err != nil {
 			m.err = fmt.Errorf("failed to read response body: %w", err)
@@ -98,38 +202,39 @@ func processFunc(ctx context.Context, httpClient HTTPClient, param *requestParam
 	}
 }
 
-func mustDoRequest(ctx context.Context, httpClient HTTPClient, param *requestParameter) ([]byte, error) {
-	retry := retryer{
-		processFunc: processFunc(ctx, httpClient, param),
-		maxRetry:    getRetryCount(),
-	}
-	if err := retry.do(ctx); err != nil {
+func mustDoRequest(ctx context.Context, r *requester, param *requestParameter) (_ []byte, err error) {
+	ctx, end := r.startOperation(ctx, param)
+	retry, err := r.do(ctx, param)
+	defer func() { end(retry, err) }()
+	if err != nil {
 		return []byte{}, err
 	}
 
 	if retry.statusCode >= 300 {
-		return retry.body, fmt.Errorf("failed to call API: %s", string(retry.body))
+		return retry.body, fmt.Errorf("failed to call API: %w", newAPIError(param, retry.statusCode, retry.body))
 	}
 
 	return retry.body, nil
 }
 
-func doRequestAndParseResponse(ctx context.Context, httpClient HTTPClient, param *requestParameter) (*Result, error) {
-	retry := retryer{
-		processFunc: processFunc(ctx, httpClient, param),
-		maxRetry:    getRetryCount(),
-	}
-	if err := retry.do(ctx); err != nil {
+func doRequestAndParseResponse(ctx context.Context, r *requester, param *requestParameter) (_ *Result, err error) {
+	ctx, end := r.startOperation(ctx, param)
+	retry, err := r.do(ctx, param)
+	defer func() { end(retry, err) }()
+	if err != nil {
 		return &Result{}, err
 	}
 
-	r, err := parseNormalResponse(retry.body)
+	result, err := parseNormalResponse(retry.body)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to parse normal response: %w", err)
 	}
 
-	r.StatusCode = retry.statusCode
-	return r, nil
+	result.StatusCode = retry.statusCode
+	if r.errorOnFailure && !result.IsSuccess {
+		return result, newAPIError(param, retry.statusCode, retry.body)
+	}
+	return result, nil
 }
 
 func parseNormalResponse(b []byte) (*Result, error) {
@@ -0,0 +1,348 @@
+package pixela
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// BackupVersion is the version of the Backup format written by Client.Backup.
+const BackupVersion = 1
+
+// Backup is a snapshot of a Pixela account made by Client.Backup.
+// It is encoded in JSON with Backup.WriteTo and decoded with ReadBackup.
+// It contains the Slack URLs of the channels, but not the token of the user.
+type Backup struct {
+	// Version is the version of the format (BackupVersion).
+	Version int `json:"version"`
+	// CreatedAt is the time when the backup was made.
+	CreatedAt time.Time `json:"createdAt"`
+	// UserName is the name of the user backed up.
+	UserName string `json:"userName"`
+	// Profile is the profile given by BackupInput.Profile, since Pixela has no API to get it.
+	Profile  *UserProfileUpdateInput `json:"profile,omitempty"`
+	Channels []ChannelDefinition     `json:"channels"`
+	Graphs   []GraphBackup           `json:"graphs"`
+	Webhooks []WebhookDefinition     `json:"webhooks"`
+}
+
+// GraphBackup is a graph in a Backup.
+type GraphBackup struct {
+	ID                  string                   `json:"id"`
+	Name                string                   `json:"name"`
+	Unit                string                   `json:"unit"`
+	Type                string                   `json:"type"`
+	Color               string                   `json:"color"`
+	TimeZone            string                   `json:"timezone,omitempty"`
+	PurgeCacheURLs      []string                 `json:"purgeCacheURLs,omitempty"`
+	SelfSufficient      string                   `json:"selfSufficient,omitempty"`
+	IsSecret            bool                     `json:"isSecret"`
+	PublishOptionalData bool                     `json:"publishOptionalData"`
+	StartOnMonday       bool                     `json:"startOnMonday,omitempty"`
+	Pixels              []PixelWithBody          `json:"pixels"`
+	Notifications       []NotificationDefinition `json:"notifications"`
+}
+
+// Backup makes a snapshot of the channels, the graphs with their pixels and notifications,
+// and the webhooks of the user. It fails at the first error so that a backup is never partial.
+func (c *Client) Backup(ctx context.Context, input *BackupInput) (*Backup, error) {
+	if err := input.Validate(); err != nil {
+		return &Backup{}, err
+	}
+
+	client := c.failingClient()
+	backup := &Backup{Version: BackupVersion, CreatedAt: time.Now(), UserName: c.UserName, Profile: input.Profile}
+
+	channels, err := client.Channel().GetAllWithContext(ctx)
+	if err != nil {
+		return &Backup{}, fmt.Errorf("failed to get channels: %w", err)
+	}
+	backup.Channels = channels.Channels
+
+	graphs, err := client.Graph().GetAllWithContext(ctx)
+	if err != nil {
+		return &Backup{}, fmt.Errorf("failed to get graphs: %w", err)
+	}
+	for _, def := range graphs.Graphs {
+		graph := GraphBackup{
+			ID:                  def.ID,
+			Name:                def.Name,
+			Unit:                def.Unit,
+			Type:                def.Type,
+			Color:               def.Color,
+			TimeZone:            def.TimeZone,
+			PurgeCacheURLs:      def.PurgeCacheURLs,
+			SelfSufficient:      def.SelfSufficient,
+			IsSecret:            def.IsSecret,
+			PublishOptionalData: def.PublishOptionalData,
+			StartOnMonday:       def.StartOnMonday,
+			Pixels:              []PixelWithBody{},
+		}
+		for p, err := range client.Graph().pixelsOf(ctx, &def, input.From, input.To, input.Concurrency) {
+			if err != nil {
+				return &Backup{}, fmt.Errorf("failed to get pixels of graph %s: %w", def.ID, err)
+			}
+			graph.Pixels = append(graph.Pixels, p)
+		}
+		notifications, err := client.Notification().GetAllWithContext(ctx, &NotificationGetAllInput{GraphID: &def.ID})
+		if err != nil {
+			return &Backup{}, fmt.Errorf("failed to get notifications of graph %s: %w", def.ID, err)
+		}
+		graph.Notifications = notifications.Notifications
+		backup.Graphs = append(backup.Graphs, graph)
+	}
+
+	webhooks, err := client.Webhook().GetAllWithContext(ctx)
+	if err != nil {
+		return &Backup{}, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	backup.Webhooks = webhooks.Webhooks
+
+	return backup, nil
+}
+
+// BackupInput is input of Client.Backup().
+type BackupInput struct {
+	// Profile is the profile of the user to store in the backup.
+	Profile *UserProfileUpdateInput
+	// From is the first date of the pixels in yyyyMMdd format (default: the first pixel of each graph).
+	From *string
+	// To is the last date of the pixels in yyyyMMdd format (default: the last pixel of each graph, even after today).
+	To *string
+	// Concurrency is the number of requests sent at a time to get the pixels of a graph (default: 1).
+	Concurrency int
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *BackupInput) Validate() error {
+	v := newValidator("BackupInput")
+	v.field("From", in.From).date()
+	v.field("To", in.To).date()
+	if in.Concurrency < 0 {
+		v.add("Concurrency", "must not be negative")
+	}
+	return v.err()
+}
+
+// Validate returns a *ValidationError if the backup is not of BackupVersion.
+func (b *Backup) Validate() error {
+	v := newValidator("Backup")
+	if b.Version != BackupVersion {
+		v.add("Version", "must be %d", BackupVersion)
+	}
+	return v.err()
+}
+
+// WriteTo writes the backup to w in JSON.
+func (b *Backup) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal json: %w", err)
+	}
+	n, err := w.Write(append(data, '\n'))
+	if err != nil {
+		return int64(n), fmt.Errorf("failed to write backup: %w", err)
+	}
+	return int64(n), nil
+}
+
+// ReadBackup reads a backup written by Backup.WriteTo from r.
+// It returns a *ValidationError if the backup is not of BackupVersion.
+func ReadBackup(r io.Reader) (*Backup, error) {
+	var backup Backup
+	if err := json.NewDecoder(r).Decode(&backup); err != nil {
+		return &Backup{}, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	if err := backup.Validate(); err != nil {
+		return &Backup{}, err
+	}
+	return &backup, nil
+}
+
+// Kinds of items restored by Client.Restore.
+const (
+	RestoreItemProfile      = "profile"
+	RestoreItemChannel      = "channel"
+	RestoreItemGraph        = "graph"
+	RestoreItemPixels       = "pixels"
+	RestoreItemNotification = "notification"
+	RestoreItemWebhook      = "webhook"
+)
+
+// RestoreReport is the result of Client.Restore.
+type RestoreReport struct {
+	// Pixels are the reports of writing the pixels by graph ID.
+	Pixels map[string]*BulkReport
+	// WebhookHashes maps the hashes of the webhooks in the backup to the hashes of the restored webhooks,
+	// since Pixela gives a new hash to a webhook when it is created.
+	WebhookHashes map[string]string
+	// Failures are the items that could not be restored.
+	Failures []RestoreFailure
+}
+
+// RestoreFailure is an item that could not be restored.
+type RestoreFailure struct {
+	// Item is the kind of the item such as RestoreItemGraph.
+	Item string
+	// ID identifies the item: the graph ID for graphs and pixels, "graphID/notificationID" for notifications,
+	// the hash in the backup for webhooks and the user name for the profile.
+	ID string
+	// Err is the reason, such as an *APIError.
+	Err error
+}
+
+func (r *RestoreReport) fail(item, id string, err error) {
+	r.Failures = append(r.Failures, RestoreFailure{Item: item, ID: id, Err: err})
+}
+
+// Restore recreates the channels, the graphs with their pixels and notifications, the webhooks
+// and the profile in the backup for the user of the client, which may differ from the user backed up.
+// Existing graphs are not changed: if a graph cannot be created, for example because it already exists,
+// its pixels, notifications and webhooks are not restored.
+// Items that could not be restored are reported instead of stopping the restore.
+// Add and subtract webhooks are reported without being restored, since the backup does not have their quantity.
+// If ctx is done, Restore stops and returns the report with ctx.Err().
+func (c *Client) Restore(ctx context.Context, backup *Backup, input *RestoreInput) (*RestoreReport, error) {
+	if err := backup.Validate(); err != nil {
+		return &RestoreReport{}, err
+	}
+	if err := input.Validate(); err != nil {
+		return &RestoreReport{}, err
+	}
+
+	client := c.failingClient()
+	report := &RestoreReport{Pixels: map[string]*BulkReport{}, WebhookHashes: map[string]string{}}
+
+	for _, ch := range backup.Channels {
+		_, err := client.Channel().CreateWithContext(ctx, &ChannelCreateInput{
+			ID:     String(ch.ID),
+			Name:   String(ch.Name),
+			Type:   String(ch.Type),
+			Detail: &SlackDetail{URL: String(ch.Detail.URL), UserName: String(ch.Detail.UserName), ChannelName: String(ch.Detail.ChannelName)},
+		})
+		if err != nil {
+			report.fail(RestoreItemChannel, ch.ID, err)
+		}
+	}
+
+	restored := map[string]bool{}
+	for _, graph := range backup.Graphs {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+		if err := client.Graph().createLike(ctx, graph.definition()); err != nil {
+			report.fail(RestoreItemGraph, graph.ID, err)
+			continue
+		}
+		restored[graph.ID] = true
+
+		writer := client.Graph().BulkWriter(&BulkWriterInput{GraphID: String(graph.ID), GraphType: String(graph.Type), Concurrency: input.Concurrency})
+		pixels, err := writer.Write(ctx, graph.pixelInputs)
+		report.Pixels[graph.ID] = pixels
+		if err == nil && pixels.Failed > 0 {
+			err = fmt.Errorf("%d of %d pixels were not written", pixels.Failed, len(graph.Pixels))
+		}
+		if err != nil {
+			report.fail(RestoreItemPixels, graph.ID, err)
+		}
+
+		for _, n := range graph.Notifications {
+			_, err := client.Notification().CreateWithContext(ctx, &NotificationCreateInput{
+				GraphID:   String(graph.ID),
+				ID:        String(n.ID),
+				Name:      String(n.Name),
+				Target:    String(n.Target),
+				Condition: String(n.Condition),
+				Threshold: String(n.Threshold),
+				RemindBy:  nonEmpty(n.RemindBy),
+				ChannelID: String(n.ChannelID),
+			})
+			if err != nil {
+				report.fail(RestoreItemNotification, graph.ID+"/"+n.ID, err)
+			}
+		}
+	}
+
+	for _, w := range backup.Webhooks {
+		if !restored[w.GraphID] {
+			report.fail(RestoreItemWebhook, w.WebhookHash, fmt.Errorf("graph %s was not restored", w.GraphID))
+			continue
+		}
+		if err := checkRecreatable(w); err != nil {
+			report.fail(RestoreItemWebhook, w.WebhookHash, err)
+			continue
+		}
+		result, err := client.Webhook().CreateWithContext(ctx, &WebhookCreateInput{GraphID: String(w.GraphID), Type: String(w.Type)})
+		if err != nil {
+			report.fail(RestoreItemWebhook, w.WebhookHash, err)
+			continue
+		}
+		report.WebhookHashes[w.WebhookHash] = result.WebhookHash
+	}
+
+	if backup.Profile != nil {
+		if _, err := client.UserProfile().UpdateWithContext(ctx, backup.Profile); err != nil {
+			report.fail(RestoreItemProfile, c.UserName, err)
+		}
+	}
+
+	return report, ctx.Err()
+}
+
+// checkRecreatable returns an error if the webhook cannot be recreated from its definition.
+// Add and subtract webhooks cannot, since WebhookDefinition does not have their quantity.
+func checkRecreatable(w WebhookDefinition) error {
+	if w.Type == WebhookTypeAdd || w.Type == WebhookTypeSubtract {
+		return fmt.Errorf("%s webhook %s cannot be recreated without its quantity", w.Type, w.WebhookHash)
+	}
+	return nil
+}
+
+// RestoreInput is input of Client.Restore().
+type RestoreInput struct {
+	// Concurrency is the number of requests sent at a time to write the pixels of a graph (default: 1).
+	Concurrency int
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *RestoreInput) Validate() error {
+	v := newValidator("RestoreInput")
+	if in.Concurrency < 0 {
+		v.add("Concurrency", "must not be negative")
+	}
+	return v.err()
+}
+
+// failingClient returns a copy of the client whose API methods return an *APIError when the API call fails.
+func (c *Client) failingClient() *Client {
+	client := *c
+	client.errorOnFailure = true
+	return &client
+}
+
+// pixelInputs yields the pixels of the graph.
+func (g *GraphBackup) pixelInputs(yield func(PixelInput) bool) {
+	for _, p := range g.Pixels {
+		if !yield(p.input()) {
+			return
+		}
+	}
+}
+
+func (g *GraphBackup) definition() *GraphDefinition {
+	return &GraphDefinition{
+		ID:                  g.ID,
+		Name:                g.Name,
+		Unit:                g.Unit,
+		Type:                g.Type,
+		Color:               g.Color,
+		TimeZone:            g.TimeZone,
+		PurgeCacheURLs:      g.PurgeCacheURLs,
+		SelfSufficient:      g.SelfSufficient,
+		IsSecret:            g.IsSecret,
+		PublishOptionalData: g.PublishOptionalData,
+		StartOnMonday:       g.StartOnMonday,
+	}
+}
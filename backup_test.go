@@ -0,0 +1,180 @@
+package pixela
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_BackupAndRestore(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	pixels := []*PixelCreateInput{
+		{GraphID: String(graphID), Date: String("20240101"), Quantity: String("1"), OptionalData: String(`{"note":"a"}`)},
+		{GraphID: String(graphID), Date: String("20240102"), Quantity: String("2")},
+	}
+	for _, p := range pixels {
+		if _, err := client.Pixel().Create(p); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+	if _, err := client.Channel().Create(&ChannelCreateInput{
+		ID: String("channel-id"), Name: String("name"), Type: String(ChannelTypeSlack),
+		Detail: &SlackDetail{URL: String("https://hooks.slack.com/services/xxx"), UserName: String("bot"), ChannelName: String("pixela")},
+	}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if _, err := client.Notification().Create(&NotificationCreateInput{
+		GraphID: String(graphID), ID: String("notification-id"), Name: String("name"), Target: String(NotificationTargetQuantity),
+		Condition: String(NotificationConditionGreaterThan), Threshold: String("1"), ChannelID: String("channel-id"),
+	}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	webhook, err := client.Webhook().Create(&WebhookCreateInput{GraphID: String(graphID), Type: String(WebhookTypeIncrement)})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	profile := &UserProfileUpdateInput{DisplayName: String("display name"), PinnedGraphID: String(graphID)}
+	backup, err := client.Backup(context.Background(), &BackupInput{Profile: profile, From: String("20231201")})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	var buf bytes.Buffer
+	if _, err := backup.WriteTo(&buf); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	backup, err = ReadBackup(&buf)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if len(backup.Graphs) != 1 || len(backup.Graphs[0].Pixels) != 2 || len(backup.Graphs[0].Notifications) != 1 {
+		t.Fatalf("got: %+v\nwant: a graph with 2 pixels and a notification", backup.Graphs)
+	}
+	if len(backup.Channels) != 1 || len(backup.Webhooks) != 1 || !reflect.DeepEqual(backup.Profile, profile) {
+		t.Fatalf("got: %+v\nwant: a channel, a webhook and the profile", backup)
+	}
+
+	other := New("other", "thisissecret", WithBaseURL(srv.URL))
+	if _, err := other.User().Create(&UserCreateInput{AgreeTermsOfService: Bool(true), NotMinor: Bool(true)}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	report, err := other.Restore(context.Background(), backup, &RestoreInput{})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if len(report.Failures) != 0 {
+		t.Errorf("got: %+v\nwant: no failures", report.Failures)
+	}
+	if report.Pixels[graphID].Written != 2 {
+		t.Errorf("got: %+v\nwant: 2 pixels written", report.Pixels[graphID].BulkProgress)
+	}
+	for date, expect := range map[string]string{"20240101": "1", "20240102": "2"} {
+		if q, _ := srv.Quantity("other", graphID, date); q != expect {
+			t.Errorf("got: %s\nwant: %s", q, expect)
+		}
+	}
+	pixel, err := other.Pixel().Get(&PixelGetInput{GraphID: String(graphID), Date: String("20240101")})
+	if err != nil || pixel.OptionalData != `{"note":"a"}` {
+		t.Errorf("got: %+v, %v\nwant: the optional data restored", pixel, err)
+	}
+	newHash, ok := report.WebhookHashes[webhook.WebhookHash]
+	if !ok || newHash == "" {
+		t.Errorf("got: %v\nwant: the new hash of %s", report.WebhookHashes, webhook.WebhookHash)
+	}
+	notifications, err := other.Notification().GetAll(&NotificationGetAllInput{GraphID: String(graphID)})
+	if err != nil || len(notifications.Notifications) != 1 {
+		t.Errorf("got: %+v, %v\nwant: the notification restored", notifications, err)
+	}
+}
+
+func TestClient_BackupFullHistory(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	future := time.Now().AddDate(0, 0, 10).Format(dateLayout)
+	dates := []string{"19990101", "20240101", future}
+	for _, date := range dates {
+		if _, err := client.Pixel().Create(&PixelCreateInput{GraphID: String(graphID), Date: String(date), Quantity: String("1")}); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+
+	backup, err := client.Backup(context.Background(), &BackupInput{})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	var got []string
+	for _, p := range backup.Graphs[0].Pixels {
+		got = append(got, p.Date)
+	}
+	if !slices.Equal(got, dates) {
+		t.Errorf("got: %v\nwant: %v", got, dates)
+	}
+}
+
+func TestClient_RestoreExistingGraph(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	backup := &Backup{
+		Version: BackupVersion,
+		Graphs: []GraphBackup{{
+			ID: graphID, Name: "name", Unit: "times", Type: GraphTypeInt, Color: GraphColorShibafu,
+			Pixels: []PixelWithBody{{Date: "20240101", Quantity: "1"}},
+		}},
+		Webhooks: []WebhookDefinition{{WebhookHash: "old-hash", GraphID: graphID, Type: WebhookTypeIncrement}},
+	}
+	report, err := client.Restore(context.Background(), backup, &RestoreInput{})
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if len(report.Failures) != 2 {
+		t.Fatalf("got: %+v\nwant: 2 failures", report.Failures)
+	}
+	if f := report.Failures[0]; f.Item != RestoreItemGraph || f.ID != graphID || errors.Is(f.Err, ErrConflict) == false {
+		t.Errorf("got: %+v\nwant: a conflict of graph %s", f, graphID)
+	}
+	if f := report.Failures[1]; f.Item != RestoreItemWebhook || f.ID != "old-hash" {
+		t.Errorf("got: %+v\nwant: a failure of webhook old-hash", f)
+	}
+	if _, ok := report.Pixels[graphID]; ok {
+		t.Errorf("got: %+v\nwant: no pixels written", report.Pixels)
+	}
+}
+
+func TestClient_RestoreAddWebhook(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	backup := &Backup{
+		Version: BackupVersion,
+		Graphs:  []GraphBackup{{ID: "new-graph", Name: "name", Unit: "times", Type: GraphTypeInt, Color: GraphColorShibafu}},
+		Webhooks: []WebhookDefinition{
+			{WebhookHash: "add-hash", GraphID: "new-graph", Type: WebhookTypeAdd},
+			{WebhookHash: "increment-hash", GraphID: "new-graph", Type: WebhookTypeIncrement},
+		},
+	}
+	report, err := client.Restore(context.Background(), backup, &RestoreInput{})
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if len(report.Failures) != 1 || report.Failures[0].Item != RestoreItemWebhook || report.Failures[0].ID != "add-hash" {
+		t.Errorf("got: %+v\nwant: a failure of webhook add-hash", report.Failures)
+	}
+	if _, ok := report.WebhookHashes["increment-hash"]; !ok {
+		t.Errorf("got: %v\nwant: the new hash of increment-hash", report.WebhookHashes)
+	}
+	webhooks, err := client.Webhook().GetAll()
+	if err != nil || len(webhooks.Webhooks) != 1 {
+		t.Errorf("got: %+v, %v\nwant: only the increment webhook", webhooks, err)
+	}
+}
+
+func TestReadBackup_UnsupportedVersion(t *testing.T) {
+	_, err := ReadBackup(strings.NewReader(`{"version": 2}`))
+
+	if errors.Is(err, ErrInvalidInput) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrInvalidInput)
+	}
+}
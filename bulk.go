@@ -0,0 +1,205 @@
+package pixela
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MaxUpdatePixels is the maximum number of pixels registered by a Graph.UpdatePixels call.
+const MaxUpdatePixels = 1000
+
+// A BulkWriter writes a large number of pixels to a graph with Graph.UpdatePixels.
+// It splits the pixels into chunks and sends up to Concurrency chunks at a time.
+// Each chunk is retried according to the RetryPolicy of the Client.
+type BulkWriter struct {
+	graph *Graph
+	input BulkWriterInput
+}
+
+// BulkWriterInput is input of Graph.BulkWriter().
+type BulkWriterInput struct {
+	// GraphID is a required field
+	GraphID *string
+	// GraphType is the type of the graph (GraphTypeInt or GraphTypeFloat).
+	// If it is given, the quantities of the pixels are checked against it.
+	GraphType *string
+	// ChunkSize is the number of pixels sent in a request (default and max: MaxUpdatePixels).
+	ChunkSize int
+	// Concurrency is the number of requests sent at a time (default: 1).
+	Concurrency int
+	// Progress is called after each chunk is sent, and after each pixel is skipped because it is invalid.
+	// The calls are not concurrent.
+	Progress func(BulkProgress)
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *BulkWriterInput) Validate() error {
+	v := newValidator("BulkWriterInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("GraphType", in.GraphType).oneOf(graphTypes...)
+	if in.ChunkSize < 0 || in.ChunkSize > MaxUpdatePixels {
+		v.add("ChunkSize", "must be from 0 to %d", MaxUpdatePixels)
+	}
+	if in.Concurrency < 0 {
+		v.add("Concurrency", "must not be negative")
+	}
+	return v.err()
+}
+
+// BulkProgress is the progress of BulkWriter.Write.
+type BulkProgress struct {
+	// Chunks is the number of chunks sent.
+	Chunks int
+	// Written is the number of pixels written.
+	Written int
+	// Failed is the number of pixels that failed to be written.
+	Failed int
+}
+
+// BulkReport is the result of BulkWriter.Write.
+type BulkReport struct {
+	BulkProgress
+	// Failures are the pixels that failed to be written in date order.
+	// They can be written again to resume.
+	Failures []BulkFailure
+}
+
+// BulkFailure is a pixel that failed to be written.
+type BulkFailure struct {
+	// Date is the date of the pixel in yyyyMMdd format.
+	Date string
+	// Pixel is the pixel.
+	Pixel PixelInput
+	// Err is the reason, such as a *ValidationError or an *APIError.
+	Err error
+}
+
+// FailedPixels returns the pixels that failed to be written.
+func (r *BulkReport) FailedPixels() []PixelInput {
+	pixels := make([]PixelInput, len(r.Failures))
+	for i, f := range r.Failures {
+		pixels[i] = f.Pixel
+	}
+	return pixels
+}
+
+// BulkWriter returns a new BulkWriter that writes pixels to the graph.
+func (g *Graph) BulkWriter(input *BulkWriterInput) *BulkWriter {
+	return &BulkWriter{graph: g, input: *input}
+}
+
+// Write writes pixels and reports the pixels written and failed.
+// Invalid pixels are reported as failures without being sent.
+// If ctx is done, Write stops reading pixels, reports the pixels read but not written as failures
+// and returns the report with ctx.Err(). If all the pixels were written anyway, the error is nil.
+func (w *BulkWriter) Write(ctx context.Context, pixels iter.Seq[PixelInput]) (*BulkReport, error) {
+	if err := w.input.Validate(); err != nil {
+		return &BulkReport{}, err
+	}
+
+	chunkSize := w.input.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = MaxUpdatePixels
+	}
+	sem := make(chan struct{}, max(w.input.Concurrency, 1))
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report BulkReport
+	)
+	record := func(chunk []PixelInput, sent bool, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if sent {
+			report.Chunks++
+		}
+		if err == nil {
+			report.Written += len(chunk)
+		} else {
+			report.Failed += len(chunk)
+			for _, p := range chunk {
+				report.Failures = append(report.Failures, BulkFailure{Date: StringValue(p.Date), Pixel: p, Err: err})
+			}
+		}
+		if w.input.Progress != nil {
+			w.input.Progress(report.BulkProgress)
+		}
+	}
+
+	var chunk []PixelInput
+	send := func() bool {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+		wg.Add(1)
+		go func(chunk []PixelInput) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			record(chunk, true, w.writeChunk(ctx, chunk))
+		}(chunk)
+		chunk = nil
+		return true
+	}
+
+	for p := range pixels {
+		if ctx.Err() != nil {
+			record([]PixelInput{p}, false, ctx.Err())
+			break
+		}
+		if err := w.validate(p); err != nil {
+			record([]PixelInput{p}, false, err)
+			continue
+		}
+		chunk = append(chunk, p)
+		if len(chunk) == chunkSize && !send() {
+			break
+		}
+	}
+	if len(chunk) > 0 && ctx.Err() == nil {
+		send()
+	}
+	if len(chunk) > 0 {
+		record(chunk, false, ctx.Err())
+	}
+	wg.Wait()
+
+	slices.SortStableFunc(report.Failures, func(a, b BulkFailure) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+	canceled := func(f BulkFailure) bool { return errors.Is(f.Err, ctx.Err()) }
+	if ctx.Err() != nil && slices.ContainsFunc(report.Failures, canceled) {
+		return &report, ctx.Err()
+	}
+	return &report, nil
+}
+
+// validate returns a *ValidationError if the pixel is invalid for the graph.
+func (w *BulkWriter) validate(p PixelInput) error {
+	v := newValidator("PixelInput")
+	p.validate(v, "", StringValue(w.input.GraphType))
+	return v.err()
+}
+
+func (w *BulkWriter) writeChunk(ctx context.Context, chunk []PixelInput) error {
+	input := &GraphUpdatePixelsInput{ID: w.input.GraphID, Pixels: chunk}
+	result, err := w.graph.UpdatePixelsWithContext(ctx, input)
+	if err != nil {
+		return err
+	}
+	if !result.IsSuccess {
+		param, err := w.graph.createUpdatePixelsRequestParameter(input)
+		if err != nil {
+			return fmt.Errorf("failed to create update pixels parameter: %w", err)
+		}
+		return newResultError(param, result)
+	}
+	return nil
+}
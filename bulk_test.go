@@ -0,0 +1,172 @@
+package pixela
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ebc-2in2crc/pixela4go/pixelatest"
+)
+
+func newBulkTestClient(t *testing.T) (*pixelatest.Server, *Client) {
+	t.Helper()
+
+	srv := pixelatest.NewServer()
+	t.Cleanup(srv.Close)
+	client := New(userName, "thisissecret", WithBaseURL(srv.URL))
+	if _, err := client.User().Create(&UserCreateInput{AgreeTermsOfService: Bool(true), NotMinor: Bool(true)}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if _, err := client.Graph().Create(&GraphCreateInput{
+		ID: String(graphID), Name: String("name"), Unit: String("times"), Type: String(GraphTypeInt), Color: String(GraphColorShibafu),
+	}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	return srv, client
+}
+
+func bulkTestPixels(n int) []PixelInput {
+	pixels := make([]PixelInput, n)
+	for i := range pixels {
+		pixels[i] = PixelInput{Date: String(fmt.Sprintf("202401%02d", i+1)), Quantity: String(fmt.Sprint(i + 1))}
+	}
+	return pixels
+}
+
+func TestBulkWriter_Write(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	pixels := append(bulkTestPixels(25), PixelInput{Date: String("20240230"), Quantity: String("1")})
+	var progress []BulkProgress
+	writer := client.Graph().BulkWriter(&BulkWriterInput{
+		GraphID:     String(graphID),
+		ChunkSize:   10,
+		Concurrency: 3,
+		Progress:    func(p BulkProgress) { progress = append(progress, p) },
+	})
+	report, err := writer.Write(context.Background(), slices.Values(pixels))
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := BulkProgress{Chunks: 3, Written: 25, Failed: 1}
+	if report.BulkProgress != expect {
+		t.Errorf("got: %+v\nwant: %+v", report.BulkProgress, expect)
+	}
+	if len(report.Failures) != 1 || report.Failures[0].Date != "20240230" || errors.Is(report.Failures[0].Err, ErrInvalidInput) == false {
+		t.Errorf("got: %+v\nwant: a failure of 20240230", report.Failures)
+	}
+	if len(progress) != 4 || progress[len(progress)-1] != expect {
+		t.Errorf("got: %+v\nwant: 4 progresses ending with %+v", progress, expect)
+	}
+	for _, p := range pixels[:25] {
+		if q, _ := srv.Quantity(userName, graphID, *p.Date); q != *p.Quantity {
+			t.Errorf("got: %s\nwant: %s", q, *p.Quantity)
+		}
+	}
+}
+
+func TestBulkWriter_WriteGraphType(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	pixels := append(bulkTestPixels(2), PixelInput{Date: String("20240110"), Quantity: String("1.5")})
+	writer := client.Graph().BulkWriter(&BulkWriterInput{GraphID: String(graphID), GraphType: String(GraphTypeInt)})
+	report, err := writer.Write(context.Background(), slices.Values(pixels))
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := BulkProgress{Chunks: 1, Written: 2, Failed: 1}
+	if report.BulkProgress != expect {
+		t.Errorf("got: %+v\nwant: %+v", report.BulkProgress, expect)
+	}
+	if len(report.Failures) != 1 || report.Failures[0].Date != "20240110" || errors.Is(report.Failures[0].Err, ErrInvalidInput) == false {
+		t.Errorf("got: %+v\nwant: a failure of 20240110", report.Failures)
+	}
+	if _, ok := srv.Quantity(userName, graphID, "20240110"); ok {
+		t.Errorf("got: the pixel of 20240110\nwant: not sent")
+	}
+}
+
+func TestBulkWriter_WriteRejected(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	srv.RejectNext(1)
+	pixels := bulkTestPixels(20)
+	writer := client.Graph().BulkWriter(&BulkWriterInput{GraphID: String(graphID), ChunkSize: 10})
+	report, err := writer.Write(context.Background(), slices.Values(pixels))
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := BulkProgress{Chunks: 2, Written: 10, Failed: 10}
+	if report.BulkProgress != expect {
+		t.Errorf("got: %+v\nwant: %+v", report.BulkProgress, expect)
+	}
+	for i, f := range report.Failures {
+		if f.Date != *pixels[i].Date {
+			t.Errorf("got: %s\nwant: %s", f.Date, *pixels[i].Date)
+		}
+		if errors.Is(f.Err, ErrAPICallRejected) == false {
+			t.Errorf("got: %v\nwant: %v", f.Err, ErrAPICallRejected)
+		}
+	}
+
+	report, err = writer.Write(context.Background(), slices.Values(report.FailedPixels()))
+	if err != nil || report.Written != 10 {
+		t.Errorf("got: %+v, %v\nwant: 10 pixels written", report.BulkProgress, err)
+	}
+}
+
+func TestBulkWriter_WriteCanceled(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	writer := client.Graph().BulkWriter(&BulkWriterInput{GraphID: String(graphID), ChunkSize: 10})
+	read := 0
+	pixels := func(yield func(PixelInput) bool) {
+		for _, p := range bulkTestPixels(5) {
+			read++
+			if !yield(p) {
+				return
+			}
+		}
+	}
+	report, err := writer.Write(ctx, pixels)
+
+	if errors.Is(err, context.Canceled) == false {
+		t.Errorf("got: %v\nwant: %v", err, context.Canceled)
+	}
+	if read != 1 {
+		t.Errorf("got: %d pixels read\nwant: 1", read)
+	}
+	if report.Failed != 1 || report.Chunks != 0 {
+		t.Errorf("got: %+v\nwant: the pixel read failed without requests", report.BulkProgress)
+	}
+}
+
+func TestBulkWriter_WriteCanceledAfterWritten(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	writer := client.Graph().BulkWriter(&BulkWriterInput{
+		GraphID:  String(graphID),
+		Progress: func(BulkProgress) { cancel() },
+	})
+	report, err := writer.Write(ctx, slices.Values(bulkTestPixels(3)))
+
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+	if report.Written != 3 || report.Failed != 0 {
+		t.Errorf("got: %+v\nwant: 3 pixels written", report.BulkProgress)
+	}
+}
+
+func TestBulkWriter_WriteInvalidInput(t *testing.T) {
+	writer := New(userName, token).Graph().BulkWriter(&BulkWriterInput{ChunkSize: MaxUpdatePixels + 1})
+	_, err := writer.Write(context.Background(), slices.Values(bulkTestPixels(1)))
+
+	if errors.Is(err, ErrInvalidInput) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrInvalidInput)
+	}
+}
@@ -0,0 +1,242 @@
+package pixela
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// A Channel manages communication with the Pixela channel API.
+type Channel struct {
+	UserName  string
+	Token     string
+	requester *requester
+	baseURL   string
+}
+
+// Specify the type of the channel.
+const (
+	ChannelTypeSlack = "slack"
+)
+
+// SlackDetail is the detail of the Slack channel.
+type SlackDetail struct {
+	// URL is a required field. It is the Incoming Webhook URL of Slack.
+	URL *string `json:"url"`
+	// UserName is a required field. It is the user name displayed when notifying.
+	UserName *string `json:"userName"`
+	// ChannelName is a required field. It is the name of the Slack channel to be notified.
+	ChannelName *string `json:"channelName"`
+}
+
+func validateSlackDetail(v *validator, detail *SlackDetail) {
+	if detail == nil {
+		v.add("Detail", "is required")
+		return
+	}
+	v.field("Detail.URL", detail.URL).required().httpsURL()
+	v.field("Detail.UserName", detail.UserName).required()
+	v.field("Detail.ChannelName", detail.ChannelName).required()
+}
+
+// Create creates a new channel.
+func (c *Channel) Create(input *ChannelCreateInput) (*Result, error) {
+	return c.CreateWithContext(context.Background(), input)
+}
+
+// CreateWithContext creates a new channel.
+func (c *Channel) CreateWithContext(ctx context.Context, input *ChannelCreateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	param, err := c.createCreateRequestParameter(input)
+	if err != nil {
+		return &Result{}, fmt.Errorf("failed to create channel create parameter: %w", err)
+	}
+
+	return doRequestAndParseResponse(ctx, c.requester, param)
+}
+
+// ChannelCreateInput is input of Channel.Create().
+type ChannelCreateInput struct {
+	// ID is a required field
+	ID *string `json:"id"`
+	// Name is a required field
+	Name *string `json:"name"`
+	// Type is a required field
+	Type *string `json:"type"`
+	// Detail is a required field
+	Detail *SlackDetail `json:"detail"`
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *ChannelCreateInput) Validate() error {
+	v := newValidator("ChannelCreateInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Name", in.Name).required()
+	v.field("Type", in.Type).required().oneOf(ChannelTypeSlack)
+	validateSlackDetail(v, in.Detail)
+	return v.err()
+}
+
+func (c *Channel) createCreateRequestParameter(input *ChannelCreateInput) (*requestParameter, error) {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return &requestParameter{}, fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	return &requestParameter{
+		Operation: "pixela.Channel.Create",
+		Method:    http.MethodPost,
+		URL:       c.baseURL + fmt.Sprintf("/v1/users/%s/channels", c.UserName),
+		Header:    map[string]string{userToken: c.Token},
+		Body:      b,
+	}, nil
+}
+
+// GetAll gets all predefined channels.
+func (c *Channel) GetAll() (*ChannelDefinitions, error) {
+	return c.GetAllWithContext(context.Background())
+}
+
+// GetAllWithContext gets all predefined channels.
+func (c *Channel) GetAllWithContext(ctx context.Context) (*ChannelDefinitions, error) {
+	b, status, err := doRequest(ctx, c.requester, c.createGetAllRequestParameter())
+	if err != nil {
+		return &ChannelDefinitions{}, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	var definitions ChannelDefinitions
+	definitions.StatusCode = status
+	if err := json.Unmarshal(b, &definitions); err != nil {
+		return &ChannelDefinitions{}, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+
+	definitions.IsSuccess = definitions.Message == ""
+	return &definitions, nil
+}
+
+// ChannelDefinitions is channel definition list.
+type ChannelDefinitions struct {
+	Channels []ChannelDefinition `json:"channels"`
+	Result
+}
+
+// ChannelDefinition is channel definition.
+type ChannelDefinition struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Detail SlackDetailOutput `json:"detail"`
+}
+
+// SlackDetailOutput is the detail of the Slack channel in a channel definition.
+type SlackDetailOutput struct {
+	URL         string `json:"url"`
+	UserName    string `json:"userName"`
+	ChannelName string `json:"channelName"`
+}
+
+func (c *Channel) createGetAllRequestParameter() *requestParameter {
+	return &requestParameter{
+		Operation: "pixela.Channel.GetAll",
+		Method:    http.MethodGet,
+		URL:       c.baseURL + fmt.Sprintf("/v1/users/%s/channels", c.UserName),
+		Header:    map[string]string{userToken: c.Token},
+		Body:      []byte{},
+	}
+}
+
+// Update updates the predefined channel.
+func (c *Channel) Update(input *ChannelUpdateInput) (*Result, error) {
+	return c.UpdateWithContext(context.Background(), input)
+}
+
+// UpdateWithContext updates the predefined channel.
+func (c *Channel) UpdateWithContext(ctx context.Context, input *ChannelUpdateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	param, err := c.createUpdateRequestParameter(input)
+	if err != nil {
+		return &Result{}, fmt.Errorf("failed to create channel update parameter: %w", err)
+	}
+
+	return doRequestAndParseResponse(ctx, c.requester, param)
+}
+
+// ChannelUpdateInput is input of Channel.Update().
+type ChannelUpdateInput struct {
+	// ID is a required field
+	ID *string `json:"-"`
+	// Name is a required field
+	Name *string `json:"name"`
+	// Type is a required field
+	Type *string `json:"type"`
+	// Detail is a required field
+	Detail *SlackDetail `json:"detail"`
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *ChannelUpdateInput) Validate() error {
+	v := newValidator("ChannelUpdateInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Name", in.Name).required()
+	v.field("Type", in.Type).required().oneOf(ChannelTypeSlack)
+	validateSlackDetail(v, in.Detail)
+	return v.err()
+}
+
+func (c *Channel) createUpdateRequestParameter(input *ChannelUpdateInput) (*requestParameter, error) {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return &requestParameter{}, fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	ID := StringValue(input.ID)
+	return &requestParameter{
+		Operation: "pixela.Channel.Update",
+		Method:    http.MethodPut,
+		URL:       c.baseURL + fmt.Sprintf("/v1/users/%s/channels/%s", c.UserName, ID),
+		Header:    map[string]string{userToken: c.Token},
+		Body:      b,
+	}, nil
+}
+
+// Delete deletes the predefined channel.
+func (c *Channel) Delete(input *ChannelDeleteInput) (*Result, error) {
+	return c.DeleteWithContext(context.Background(), input)
+}
+
+// DeleteWithContext deletes the predefined channel.
+func (c *Channel) DeleteWithContext(ctx context.Context, input *ChannelDeleteInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, c.requester, c.createDeleteRequestParameter(input))
+}
+
+// ChannelDeleteInput is input of Channel.Delete().
+type ChannelDeleteInput struct {
+	// ID is a required field
+	ID *string
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *ChannelDeleteInput) Validate() error {
+	v := newValidator("ChannelDeleteInput")
+	v.field("ID", in.ID).required().id()
+	return v.err()
+}
+
+func (c *Channel) createDeleteRequestParameter(input *ChannelDeleteInput) *requestParameter {
+	ID := StringValue(input.ID)
+	return &requestParameter{
+		Operation: "pixela.Channel.Delete",
+		Method:    http.MethodDelete,
+		URL:       c.baseURL + fmt.Sprintf("/v1/users/%s/channels/%s", c.UserName, ID),
+		Header:    map[string]string{userToken: c.Token},
+		Body:      []byte{},
+	}
+}
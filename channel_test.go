@@ -0,0 +1,285 @@
+package pixela
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func newSlackDetail() *SlackDetail {
+	return &SlackDetail{
+		URL:         String("https://hooks.slack.com/services/xxxx"),
+		UserName:    String("slack-user-name"),
+		ChannelName: String("slack-channel-name"),
+	}
+}
+
+func TestChannel_CreateCreateRequestParameter(t *testing.T) {
+	client := New(userName, token)
+	input := &ChannelCreateInput{
+		ID:     String("channel-id"),
+		Name:   String("channel-name"),
+		Type:   String(ChannelTypeSlack),
+		Detail: newSlackDetail(),
+	}
+	param, err := client.Channel().createCreateRequestParameter(input)
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+
+	if param.Method != http.MethodPost {
+		t.Errorf("request method: %s\nwant: %s", param.Method, http.MethodPost)
+	}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/channels", userName)
+	if param.URL != expect {
+		t.Errorf("URL: %s\nwant: %s", param.URL, expect)
+	}
+
+	if param.Header[userToken] != token {
+		t.Errorf("%s: %s\nwant: %s", userToken, param.Header[userToken], token)
+	}
+
+	s := `{"id":"channel-id","name":"channel-name","type":"slack","detail":{"url":"https://hooks.slack.com/services/xxxx","userName":"slack-user-name","channelName":"slack-channel-name"}}`
+	b := []byte(s)
+	if bytes.Equal(param.Body, b) == false {
+		t.Errorf("Body: %s\nwant: %s", string(param.Body), s)
+	}
+}
+
+func TestChannel_Create(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newOKMock()
+	input := &ChannelCreateInput{
+		ID:     String("channel-id"),
+		Name:   String("channel-name"),
+		Type:   String(ChannelTypeSlack),
+		Detail: newSlackDetail(),
+	}
+	result, err := client.Channel().Create(input)
+
+	testSuccess(t, result, err)
+}
+
+func TestChannel_CreateFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	input := &ChannelCreateInput{
+		ID:     String("channel-id"),
+		Name:   String("channel-name"),
+		Type:   String(ChannelTypeSlack),
+		Detail: newSlackDetail(),
+	}
+	result, err := client.Channel().Create(input)
+
+	testAPIFailedResult(t, result, err)
+}
+
+func TestChannel_CreateError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	input := &ChannelCreateInput{
+		ID:     String("channel-id"),
+		Name:   String("channel-name"),
+		Type:   String(ChannelTypeSlack),
+		Detail: newSlackDetail(),
+	}
+	_, err := client.Channel().Create(input)
+
+	testPageNotFoundError(t, err)
+}
+
+func TestChannel_CreateGetAllRequestParameter(t *testing.T) {
+	client := New(userName, token)
+	param := client.Channel().createGetAllRequestParameter()
+
+	if param.Method != http.MethodGet {
+		t.Errorf("request method: %s\nwant: %s", param.Method, http.MethodGet)
+	}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/channels", userName)
+	if param.URL != expect {
+		t.Errorf("URL: %s\nwant: %s", param.URL, expect)
+	}
+
+	if param.Header[userToken] != token {
+		t.Errorf("%s: %s\nwant: %s", userToken, param.Header[userToken], token)
+	}
+
+	if bytes.Equal(param.Body, []byte{}) == false {
+		t.Errorf("Body: %s\nwant: \"\"", string(param.Body))
+	}
+}
+
+func TestChannel_GetAll(t *testing.T) {
+	s := `{"channels":[{"id":"channel-id","name":"channel-name","type":"slack","detail":{"url":"https://hooks.slack.com/services/xxxx","userName":"slack-user-name","channelName":"slack-channel-name"}}]}`
+	b := []byte(s)
+	client := New(userName, token)
+	client.HTTPClient = &httpClientMock{statusCode: http.StatusOK, body: b}
+	definitions, err := client.Channel().GetAll()
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+
+	expect := &ChannelDefinitions{
+		Channels: []ChannelDefinition{
+			{
+				ID:   "channel-id",
+				Name: "channel-name",
+				Type: ChannelTypeSlack,
+				Detail: SlackDetailOutput{
+					URL:         "https://hooks.slack.com/services/xxxx",
+					UserName:    "slack-user-name",
+					ChannelName: "slack-channel-name",
+				},
+			},
+		},
+		Result: Result{IsSuccess: true, StatusCode: http.StatusOK},
+	}
+	if reflect.DeepEqual(definitions, expect) == false {
+		t.Errorf("got: %v\nwant: %v", definitions, expect)
+	}
+}
+
+func TestChannel_GetAllFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	result, err := client.Channel().GetAll()
+
+	testAPIFailedResult(t, &result.Result, err)
+}
+
+func TestChannel_GetAllError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	_, err := client.Channel().GetAll()
+
+	testPageNotFoundError(t, err)
+}
+
+func TestChannel_CreateUpdateRequestParameter(t *testing.T) {
+	client := New(userName, token)
+	input := &ChannelUpdateInput{
+		ID:     String("channel-id"),
+		Name:   String("channel-name"),
+		Type:   String(ChannelTypeSlack),
+		Detail: newSlackDetail(),
+	}
+	param, err := client.Channel().createUpdateRequestParameter(input)
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+
+	if param.Method != http.MethodPut {
+		t.Errorf("request method: %s\nwant: %s", param.Method, http.MethodPut)
+	}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/channels/channel-id", userName)
+	if param.URL != expect {
+		t.Errorf("URL: %s\nwant: %s", param.URL, expect)
+	}
+
+	if param.Header[userToken] != token {
+		t.Errorf("%s: %s\nwant: %s", userToken, param.Header[userToken], token)
+	}
+
+	s := `{"name":"channel-name","type":"slack","detail":{"url":"https://hooks.slack.com/services/xxxx","userName":"slack-user-name","channelName":"slack-channel-name"}}`
+	b := []byte(s)
+	if bytes.Equal(param.Body, b) == false {
+		t.Errorf("Body: %s\nwant: %s", string(param.Body), s)
+	}
+}
+
+func TestChannel_Update(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newOKMock()
+	input := &ChannelUpdateInput{
+		ID:     String("channel-id"),
+		Name:   String("channel-name"),
+		Type:   String(ChannelTypeSlack),
+		Detail: newSlackDetail(),
+	}
+	result, err := client.Channel().Update(input)
+
+	testSuccess(t, result, err)
+}
+
+func TestChannel_UpdateFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	input := &ChannelUpdateInput{
+		ID:     String("channel-id"),
+		Name:   String("channel-name"),
+		Type:   String(ChannelTypeSlack),
+		Detail: newSlackDetail(),
+	}
+	result, err := client.Channel().Update(input)
+
+	testAPIFailedResult(t, result, err)
+}
+
+func TestChannel_UpdateError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	input := &ChannelUpdateInput{
+		ID:     String("channel-id"),
+		Name:   String("channel-name"),
+		Type:   String(ChannelTypeSlack),
+		Detail: newSlackDetail(),
+	}
+	_, err := client.Channel().Update(input)
+
+	testPageNotFoundError(t, err)
+}
+
+func TestChannel_CreateDeleteRequestParameter(t *testing.T) {
+	client := New(userName, token)
+	input := &ChannelDeleteInput{ID: String("channel-id")}
+	param := client.Channel().createDeleteRequestParameter(input)
+
+	if param.Method != http.MethodDelete {
+		t.Errorf("request method: %s\nwant: %s", param.Method, http.MethodDelete)
+	}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/channels/channel-id", userName)
+	if param.URL != expect {
+		t.Errorf("URL: %s\nwant: %s", param.URL, expect)
+	}
+
+	if param.Header[userToken] != token {
+		t.Errorf("%s: %s\nwant: %s", userToken, param.Header[userToken], token)
+	}
+
+	if bytes.Equal(param.Body, []byte{}) == false {
+		t.Errorf("Body: %s\nwant: \"\"", string(param.Body))
+	}
+}
+
+func TestChannel_Delete(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newOKMock()
+	input := &ChannelDeleteInput{ID: String("channel-id")}
+	result, err := client.Channel().Delete(input)
+
+	testSuccess(t, result, err)
+}
+
+func TestChannel_DeleteFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	input := &ChannelDeleteInput{ID: String("channel-id")}
+	result, err := client.Channel().Delete(input)
+
+	testAPIFailedResult(t, result, err)
+}
+
+func TestChannel_DeleteError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	input := &ChannelDeleteInput{ID: String("channel-id")}
+	_, err := client.Channel().Delete(input)
+
+	testPageNotFoundError(t, err)
+}
@@ -1,44 +1,144 @@
 package pixela
 
-import "net/http"
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+)
 
 // A Client manages communication with the Pixela User API.
 type Client struct {
-	UserName   string
-	Token      string
-	HTTPClient HTTPClient
+	UserName       string
+	Token          string
+	HTTPClient     HTTPClient
+	baseURL        string
+	retryPolicy    *RetryPolicy
+	errorOnFailure bool
+	rateLimiter    *RateLimiter
+	middlewares    []Middleware
+	logger         *slog.Logger
+	logLevels      *LogLevels
+	tracer         Tracer
+}
+
+// An Option configures a Client.
+type Option func(c *Client)
+
+// WithBaseURL sets the base URL for API requests (default: APIBaseURL).
+// It is useful for pointing the client at a proxy, a gateway or a fake server in tests.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTPClient used for API requests (default: &http.Client{}).
+func WithHTTPClient(httpClient HTTPClient) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithRetryPolicy sets the policy to retry API calls of the client.
+// If it is not set, the client retries rejected API calls RetryCount times.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		policy.RetryableStatusCodes = slices.Clone(policy.RetryableStatusCodes)
+		c.retryPolicy = &policy
+	}
+}
+
+// WithErrorOnFailure makes API methods return an *APIError as error when the API call is not successful,
+// in addition to the Result, instead of returning the unsuccessful Result with nil error.
+func WithErrorOnFailure() Option {
+	return func(c *Client) {
+		c.errorOnFailure = true
+	}
+}
+
+// WithRateLimiter makes the client wait for the RateLimiter before each attempt of API calls.
+// The RateLimiter is shared by all sub-clients built from the client, and can be shared by other clients.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithMiddleware adds middlewares that wrap the HTTPClient for every attempt of API calls.
+// The first middleware is the outermost.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
 }
 
 // New return a new Client instance.
-func New(userName, token string) *Client {
-	return &Client{
+func New(userName, token string, opts ...Option) *Client {
+	c := &Client{
 		UserName:   userName,
 		Token:      token,
 		HTTPClient: &http.Client{},
+		baseURL:    APIBaseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BaseURL returns the base URL for API requests.
+func (c *Client) BaseURL() string {
+	if c.baseURL == "" {
+		return APIBaseURL
 	}
+	return c.baseURL
 }
 
 // User returns a new Pixela user API client.
 func (c *Client) User() *User {
-	return &User{UserName: c.UserName, Token: c.Token, httpClient: c.HTTPClient}
+	return &User{UserName: c.UserName, Token: c.Token, requester: c.requester(), baseURL: c.BaseURL()}
 }
 
 // UserProfile returns a new Pixela user profile API client.
 func (c *Client) UserProfile() *UserProfile {
-	return &UserProfile{UserName: c.UserName, Token: c.Token, httpClient: c.HTTPClient}
+	return &UserProfile{UserName: c.UserName, Token: c.Token, requester: c.requester(), baseURL: c.BaseURL()}
 }
 
 // Graph returns a new Pixela graph API client.
 func (c *Client) Graph() *Graph {
-	return &Graph{UserName: c.UserName, Token: c.Token, httpClient: c.HTTPClient}
+	return &Graph{UserName: c.UserName, Token: c.Token, requester: c.requester(), baseURL: c.BaseURL()}
 }
 
 // Pixel returns a new Pixela pixel API client.
 func (c *Client) Pixel() *Pixel {
-	return &Pixel{UserName: c.UserName, Token: c.Token, httpClient: c.HTTPClient}
+	return &Pixel{UserName: c.UserName, Token: c.Token, requester: c.requester(), baseURL: c.BaseURL()}
 }
 
 // Webhook returns a new Pixela webhook API client.
 func (c *Client) Webhook() *Webhook {
-	return &Webhook{UserName: c.UserName, Token: c.Token, httpClient: c.HTTPClient}
+	return &Webhook{UserName: c.UserName, Token: c.Token, requester: c.requester(), baseURL: c.BaseURL()}
+}
+
+// Channel returns a new Pixela channel API client.
+func (c *Client) Channel() *Channel {
+	return &Channel{UserName: c.UserName, Token: c.Token, requester: c.requester(), baseURL: c.BaseURL()}
+}
+
+// Notification returns a new Pixela graph notification API client.
+func (c *Client) Notification() *Notification {
+	return &Notification{UserName: c.UserName, Token: c.Token, requester: c.requester(), baseURL: c.BaseURL()}
+}
+
+func (c *Client) requester() *requester {
+	return &requester{
+		httpClient:     c.HTTPClient,
+		retryPolicy:    c.retryPolicy,
+		errorOnFailure: c.errorOnFailure,
+		rateLimiter:    c.rateLimiter,
+		middlewares:    c.middlewares,
+		logger:         c.logger,
+		logLevels:      c.logLevels,
+		tracer:         c.tracer,
+	}
 }
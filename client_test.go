@@ -0,0 +1,130 @@
+package pixela
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	client := New(userName, token)
+
+	if client.BaseURL() != APIBaseURL {
+		t.Errorf("got: %s\nwant: %s", client.BaseURL(), APIBaseURL)
+	}
+}
+
+func TestNewWithHTTPClient(t *testing.T) {
+	mock := newOKMock()
+	client := New(userName, token, WithHTTPClient(mock))
+
+	if client.HTTPClient != mock {
+		t.Errorf("got: %v\nwant: %v", client.HTTPClient, mock)
+	}
+}
+
+func TestNewWithBaseURL(t *testing.T) {
+	baseURL := "http://localhost:8080"
+	client := New(userName, token, WithBaseURL(baseURL+"/"))
+
+	if client.BaseURL() != baseURL {
+		t.Errorf("got: %s\nwant: %s", client.BaseURL(), baseURL)
+	}
+
+	params := []struct {
+		actual string
+		expect string
+	}{
+		{
+			actual: client.User().createDeleteRequestParameter().URL,
+			expect: fmt.Sprintf(baseURL+"/v1/users/%s", userName),
+		},
+		{
+			actual: client.Graph().createGetAllRequestParameter().URL,
+			expect: fmt.Sprintf(baseURL+"/v1/users/%s/graphs", userName),
+		},
+		{
+			actual: client.Graph().URL(&GraphURLInput{ID: String(graphID)}),
+			expect: fmt.Sprintf(baseURL+"/v1/users/%s/graphs/%s.html", userName, graphID),
+		},
+		{
+			actual: client.Pixel().createIncrementRequestParameter(&PixelIncrementInput{GraphID: String(graphID)}).URL,
+			expect: fmt.Sprintf(baseURL+"/v1/users/%s/graphs/%s/increment", userName, graphID),
+		},
+		{
+			actual: client.Webhook().createGetAllRequestParameter().URL,
+			expect: fmt.Sprintf(baseURL+"/v1/users/%s/webhooks", userName),
+		},
+		{
+			actual: client.Channel().createGetAllRequestParameter().URL,
+			expect: fmt.Sprintf(baseURL+"/v1/users/%s/channels", userName),
+		},
+		{
+			actual: client.Notification().createGetAllRequestParameter(&NotificationGetAllInput{GraphID: String(graphID)}).URL,
+			expect: fmt.Sprintf(baseURL+"/v1/users/%s/graphs/%s/notifications", userName, graphID),
+		},
+		{
+			actual: client.UserProfile().URL(),
+			expect: fmt.Sprintf(baseURL+"/@%s", userName),
+		},
+	}
+
+	for _, p := range params {
+		if p.actual != p.expect {
+			t.Errorf("got: %s\nwant: %s", p.actual, p.expect)
+		}
+	}
+}
+
+func TestNewWithBaseURL_PercentEncoded(t *testing.T) {
+	baseURL := "http://localhost:8080/%7Euser"
+	client := New(userName, token, WithBaseURL(baseURL))
+
+	params := []struct {
+		actual string
+		expect string
+	}{
+		{
+			actual: client.User().createDeleteRequestParameter().URL,
+			expect: baseURL + "/v1/users/" + userName,
+		},
+		{
+			actual: client.Graph().URL(&GraphURLInput{ID: String(graphID)}),
+			expect: baseURL + "/v1/users/" + userName + "/graphs/" + graphID + ".html",
+		},
+		{
+			actual: client.Pixel().createIncrementRequestParameter(&PixelIncrementInput{GraphID: String(graphID)}).URL,
+			expect: baseURL + "/v1/users/" + userName + "/graphs/" + graphID + "/increment",
+		},
+		{
+			actual: client.Webhook().createGetAllRequestParameter().URL,
+			expect: baseURL + "/v1/users/" + userName + "/webhooks",
+		},
+		{
+			actual: client.Channel().createGetAllRequestParameter().URL,
+			expect: baseURL + "/v1/users/" + userName + "/channels",
+		},
+		{
+			actual: client.Notification().createGetAllRequestParameter(&NotificationGetAllInput{GraphID: String(graphID)}).URL,
+			expect: baseURL + "/v1/users/" + userName + "/graphs/" + graphID + "/notifications",
+		},
+		{
+			actual: client.UserProfile().URL(),
+			expect: baseURL + "/@" + userName,
+		},
+	}
+
+	for _, p := range params {
+		if p.actual != p.expect {
+			t.Errorf("got: %s\nwant: %s", p.actual, p.expect)
+		}
+	}
+}
+
+func TestClientZeroValueBaseURL(t *testing.T) {
+	client := &Client{UserName: userName, Token: token}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs", userName)
+	if url := client.Graph().createGetAllRequestParameter().URL; url != expect {
+		t.Errorf("got: %s\nwant: %s", url, expect)
+	}
+}
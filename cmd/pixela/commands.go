@@ -0,0 +1,614 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error)
+}
+
+var commands = []command{
+	{name: "user create", summary: "Create a new user", run: userCreate},
+	{name: "user update", summary: "Update the token of the user", run: userUpdate},
+	{name: "user delete", summary: "Delete the user", run: userDelete},
+	{name: "profile update", summary: "Update the profile of the user", run: profileUpdate},
+	{name: "profile url", summary: "Print the URL of the profile page", run: profileURL},
+	{name: "graph create", summary: "Create a new graph", run: graphCreate},
+	{name: "graph list", summary: "List all graph definitions", run: graphList},
+	{name: "graph get", summary: "Get a graph definition", run: graphGet},
+	{name: "graph update", summary: "Update a graph definition", run: graphUpdate},
+	{name: "graph delete", summary: "Delete a graph", run: graphDelete},
+	{name: "graph stats", summary: "Get the statistics of a graph", run: graphStats},
+	{name: "graph svg", summary: "Get a graph as SVG", run: graphSVG},
+	{name: "graph url", summary: "Print the URL of the graph page", run: graphURL},
+	{name: "graph pixels", summary: "List the pixels of a graph", run: graphPixels},
+	{name: "graph latest", summary: "Get the latest pixel of a graph", run: graphLatest},
+	{name: "graph today", summary: "Get the pixel of today", run: graphToday},
+	{name: "graph add", summary: "Add quantity to the pixel of today", run: graphAdd},
+	{name: "graph subtract", summary: "Subtract quantity from the pixel of today", run: graphSubtract},
+	{name: "graph stopwatch", summary: "Start or end the stopwatch of a graph", run: graphStopwatch},
+	{name: "graph analyze", summary: "Analyze a graph", run: graphAnalyze},
+	{name: "graph update-pixels", summary: "Create or update pixels in a JSON file", run: graphUpdatePixels},
+	{name: "pixel create", summary: "Create a pixel", run: pixelCreate},
+	{name: "pixel get", summary: "Get a pixel", run: pixelGet},
+	{name: "pixel update", summary: "Update a pixel", run: pixelUpdate},
+	{name: "pixel increment", summary: "Increment the pixel of today", run: pixelIncrement},
+	{name: "pixel decrement", summary: "Decrement the pixel of today", run: pixelDecrement},
+	{name: "pixel add", summary: "Add quantity to a pixel", run: pixelAdd},
+	{name: "pixel subtract", summary: "Subtract quantity from a pixel", run: pixelSubtract},
+	{name: "pixel delete", summary: "Delete a pixel", run: pixelDelete},
+	{name: "webhook create", summary: "Create a webhook", run: webhookCreate},
+	{name: "webhook list", summary: "List all webhooks", run: webhookList},
+	{name: "webhook invoke", summary: "Invoke a webhook", run: webhookInvoke},
+	{name: "webhook delete", summary: "Delete a webhook", run: webhookDelete},
+	{name: "channel create", summary: "Create a Slack channel", run: channelCreate},
+	{name: "channel list", summary: "List all channels", run: channelList},
+	{name: "channel update", summary: "Update a Slack channel", run: channelUpdate},
+	{name: "channel delete", summary: "Delete a channel", run: channelDelete},
+	{name: "notification create", summary: "Create a notification rule of a graph", run: notificationCreate},
+	{name: "notification list", summary: "List the notification rules of a graph", run: notificationList},
+	{name: "notification update", summary: "Update a notification rule of a graph", run: notificationUpdate},
+	{name: "notification delete", summary: "Delete a notification rule of a graph", run: notificationDelete},
+}
+
+func findCommand(resource, action string) *command {
+	for i := range commands {
+		if commands[i].name == resource+" "+action {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func userCreate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.boolFlag("agree-terms-of-service", "agree to the terms of service (required)")
+	f.boolFlag("not-minor", "you are not a minor, or you have the consent of your parental authority (required)")
+	f.stringFlag("thanks-code", "thanks code of Pixela supporter")
+	if err := f.parse(args, "agree-terms-of-service", "not-minor"); err != nil {
+		return nil, err
+	}
+	return c.User().CreateWithContext(ctx, &pixela.UserCreateInput{
+		AgreeTermsOfService: f.boolean("agree-terms-of-service"),
+		NotMinor:            f.boolean("not-minor"),
+		ThanksCode:          f.str("thanks-code"),
+	})
+}
+
+func userUpdate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("new-token", "new token (required)")
+	f.stringFlag("thanks-code", "thanks code of Pixela supporter")
+	f.boolFlag("allow-ai-processing", "allow Pixela to process your data with AI")
+	if err := f.parse(args, "new-token"); err != nil {
+		return nil, err
+	}
+	return c.User().UpdateWithContext(ctx, &pixela.UserUpdateInput{
+		NewToken:          f.str("new-token"),
+		ThanksCode:        f.str("thanks-code"),
+		AllowAIProcessing: f.boolean("allow-ai-processing"),
+	})
+}
+
+func userDelete(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	if err := f.parse(args); err != nil {
+		return nil, err
+	}
+	return c.User().DeleteWithContext(ctx)
+}
+
+func profileUpdate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("display-name", "display name")
+	f.stringFlag("gravatar-icon-email", "email address of the Gravatar icon")
+	f.stringFlag("title", "title")
+	f.stringFlag("timezone", "timezone (e.g. Asia/Tokyo)")
+	f.stringFlag("about-url", "URL of the about page")
+	f.stringFlag("contribute-urls", "comma-separated URLs of contributions")
+	f.stringFlag("pinned-graph-id", "ID of the graph pinned to the profile")
+	if err := f.parse(args); err != nil {
+		return nil, err
+	}
+	return c.UserProfile().UpdateWithContext(ctx, &pixela.UserProfileUpdateInput{
+		DisplayName:       f.str("display-name"),
+		GravatarIconEmail: f.str("gravatar-icon-email"),
+		Title:             f.str("title"),
+		Timezone:          f.str("timezone"),
+		AboutURL:          f.str("about-url"),
+		ContributeURLs:    f.list("contribute-urls"),
+		PinnedGraphID:     f.str("pinned-graph-id"),
+	})
+}
+
+func profileURL(_ context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	if err := f.parse(args); err != nil {
+		return nil, err
+	}
+	return c.UserProfile().URL(), nil
+}
+
+func graphCreate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.stringFlag("name", "graph name (required)")
+	f.stringFlag("unit", "unit of quantity (required)")
+	f.stringFlag("type", "type of quantity: int or float (required)")
+	f.stringFlag("color", "color: shibafu, momiji, sora, ichou, ajisai or kuro (required)")
+	f.stringFlag("timezone", "timezone (e.g. Asia/Tokyo)")
+	f.stringFlag("description", "description")
+	f.stringFlag("self-sufficient", "increment, decrement or none")
+	f.boolFlag("secret", "hide the graph from the public")
+	f.boolFlag("publish-optional-data", "publish the optional data of pixels")
+	f.boolFlag("start-on-monday", "start the week on Monday")
+	if err := f.parse(args, "id", "name", "unit", "type", "color"); err != nil {
+		return nil, err
+	}
+	return c.Graph().CreateWithContext(ctx, &pixela.GraphCreateInput{
+		ID:                  f.str("id"),
+		Name:                f.str("name"),
+		Unit:                f.str("unit"),
+		Type:                f.str("type"),
+		Color:               f.str("color"),
+		TimeZone:            f.str("timezone"),
+		Description:         f.str("description"),
+		SelfSufficient:      f.str("self-sufficient"),
+		IsSecret:            f.boolean("secret"),
+		PublishOptionalData: f.boolean("publish-optional-data"),
+		StartOnMonday:       f.boolean("start-on-monday"),
+	})
+}
+
+func graphList(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	if err := f.parse(args); err != nil {
+		return nil, err
+	}
+	return c.Graph().GetAllWithContext(ctx)
+}
+
+func graphGet(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().GetWithContext(ctx, &pixela.GraphGetInput{ID: f.str("id")})
+}
+
+func graphUpdate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.stringFlag("name", "graph name")
+	f.stringFlag("unit", "unit of quantity")
+	f.stringFlag("color", "color: shibafu, momiji, sora, ichou, ajisai or kuro")
+	f.stringFlag("timezone", "timezone (e.g. Asia/Tokyo)")
+	f.stringFlag("description", "description")
+	f.stringFlag("purge-cache-urls", "comma-separated URLs to purge the cache of the graph image")
+	f.stringFlag("self-sufficient", "increment, decrement or none")
+	f.boolFlag("secret", "hide the graph from the public")
+	f.boolFlag("publish-optional-data", "publish the optional data of pixels")
+	f.boolFlag("start-on-monday", "start the week on Monday")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().UpdateWithContext(ctx, &pixela.GraphUpdateInput{
+		ID:                  f.str("id"),
+		Name:                f.str("name"),
+		Unit:                f.str("unit"),
+		Color:               f.str("color"),
+		TimeZone:            f.str("timezone"),
+		Description:         f.str("description"),
+		PurgeCacheURLs:      f.list("purge-cache-urls"),
+		SelfSufficient:      f.str("self-sufficient"),
+		IsSecret:            f.boolean("secret"),
+		PublishOptionalData: f.boolean("publish-optional-data"),
+		StartOnMonday:       f.boolean("start-on-monday"),
+	})
+}
+
+func graphDelete(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().DeleteWithContext(ctx, &pixela.GraphDeleteInput{ID: f.str("id")})
+}
+
+func graphStats(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().StatsWithContext(ctx, &pixela.GraphStatsInput{ID: f.str("id")})
+}
+
+func graphSVG(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.stringFlag("date", "end date of the graph (yyyyMMdd)")
+	f.stringFlag("mode", "short, badge or line")
+	f.stringFlag("appearance", "dark")
+	f.stringFlag("less-than", "show only pixels whose quantity is less than this value")
+	f.stringFlag("greater-than", "show only pixels whose quantity is greater than this value")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().GetSVGWithContext(ctx, &pixela.GraphGetSVGInput{
+		ID:          f.str("id"),
+		Date:        f.str("date"),
+		Mode:        f.str("mode"),
+		Appearance:  f.str("appearance"),
+		LessThan:    f.str("less-than"),
+		GreaterThan: f.str("greater-than"),
+	})
+}
+
+func graphURL(_ context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.stringFlag("mode", "simple or simple-short")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().URL(&pixela.GraphURLInput{ID: f.str("id"), Mode: f.str("mode")}), nil
+}
+
+// graphPixels lists the pixels of the graph.
+// When both -from and -to are specified, the period may be longer than 365 days.
+func graphPixels(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.stringFlag("from", "start date (yyyyMMdd)")
+	f.stringFlag("to", "end date (yyyyMMdd)")
+	f.boolFlag("dates-only", "list only the dates of pixels")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+
+	input := &pixela.GraphGetPixelDatesInput{ID: f.str("id"), From: f.str("from"), To: f.str("to")}
+	if f.isSet("from") && f.isSet("to") {
+		return allPixels(ctx, c, input, pixela.BoolValue(f.boolean("dates-only")))
+	}
+	if pixela.BoolValue(f.boolean("dates-only")) {
+		return c.Graph().GetPixelDateListWithContext(ctx, input)
+	}
+	return c.Graph().GetPixelsWithBodyWithContext(ctx, input)
+}
+
+func allPixels(ctx context.Context, c *pixela.Client, input *pixela.GraphGetPixelDatesInput, datesOnly bool) (any, error) {
+	var pixels []pixela.PixelWithBody
+	all := c.Graph().AllPixels(ctx, &pixela.GraphAllPixelsInput{ID: input.ID, From: input.From, To: input.To, Concurrency: 2})
+	for p, err := range all {
+		if err != nil {
+			return nil, err
+		}
+		pixels = append(pixels, p)
+	}
+
+	if datesOnly {
+		dates := make([]string, len(pixels))
+		for i, p := range pixels {
+			dates[i] = p.Date
+		}
+		return &pixela.PixelDateList{Dates: dates, Result: pixela.Result{IsSuccess: true}}, nil
+	}
+	return &pixela.PixelsWithBody{Pixels: pixels, Result: pixela.Result{IsSuccess: true}}, nil
+}
+
+func graphLatest(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().GetLatestPixelWithContext(ctx, &pixela.GraphGetLatestPixelInput{ID: f.str("id")})
+}
+
+func graphToday(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.boolFlag("return-empty", "return an empty pixel instead of an error if it is not registered")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().GetTodayWithContext(ctx, &pixela.GraphGetTodayInput{ID: f.str("id"), ReturnEmpty: f.boolean("return-empty")})
+}
+
+func graphAdd(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.stringFlag("quantity", "quantity to add (required)")
+	if err := f.parse(args, "id", "quantity"); err != nil {
+		return nil, err
+	}
+	return c.Graph().AddWithContext(ctx, &pixela.GraphAddInput{ID: f.str("id"), Quantity: f.str("quantity")})
+}
+
+func graphSubtract(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.stringFlag("quantity", "quantity to subtract (required)")
+	if err := f.parse(args, "id", "quantity"); err != nil {
+		return nil, err
+	}
+	return c.Graph().SubtractWithContext(ctx, &pixela.GraphSubtractInput{ID: f.str("id"), Quantity: f.str("quantity")})
+}
+
+func graphStopwatch(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().StopwatchWithContext(ctx, &pixela.GraphStopwatchInput{ID: f.str("id")})
+}
+
+func graphAnalyze(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Graph().AnalyzeWithContext(ctx, &pixela.GraphAnalyzeInput{ID: f.str("id")})
+}
+
+// graphUpdatePixels reads pixels such as [{"date":"20240414","quantity":"5"}] from the file.
+func graphUpdatePixels(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "graph ID (required)")
+	f.stringFlag("file", `JSON file of pixels, or "-" for stdin (required)`)
+	if err := f.parse(args, "id", "file"); err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	var err error
+	if file := *f.strings["file"]; file == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pixels: %w", err)
+	}
+	var pixels []pixela.PixelInput
+	if err := json.Unmarshal(b, &pixels); err != nil {
+		return nil, fmt.Errorf("failed to parse pixels: %w", err)
+	}
+	return c.Graph().UpdatePixelsWithContext(ctx, &pixela.GraphUpdatePixelsInput{ID: f.str("id"), Pixels: pixels})
+}
+
+func pixelCreate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("date", "date (yyyyMMdd) (required)")
+	f.stringFlag("quantity", "quantity (required)")
+	f.stringFlag("optional-data", "optional data in JSON")
+	if err := f.parse(args, "graph", "date", "quantity"); err != nil {
+		return nil, err
+	}
+	return c.Pixel().CreateWithContext(ctx, &pixela.PixelCreateInput{
+		GraphID:      f.str("graph"),
+		Date:         f.str("date"),
+		Quantity:     f.str("quantity"),
+		OptionalData: f.str("optional-data"),
+	})
+}
+
+func pixelGet(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("date", "date (yyyyMMdd) (required)")
+	if err := f.parse(args, "graph", "date"); err != nil {
+		return nil, err
+	}
+	return c.Pixel().GetWithContext(ctx, &pixela.PixelGetInput{GraphID: f.str("graph"), Date: f.str("date")})
+}
+
+func pixelUpdate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("date", "date (yyyyMMdd) (required)")
+	f.stringFlag("quantity", "quantity")
+	f.stringFlag("optional-data", "optional data in JSON")
+	if err := f.parse(args, "graph", "date"); err != nil {
+		return nil, err
+	}
+	return c.Pixel().UpdateWithContext(ctx, &pixela.PixelUpdateInput{
+		GraphID:      f.str("graph"),
+		Date:         f.str("date"),
+		Quantity:     f.str("quantity"),
+		OptionalData: f.str("optional-data"),
+	})
+}
+
+func pixelIncrement(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	if err := f.parse(args, "graph"); err != nil {
+		return nil, err
+	}
+	return c.Pixel().IncrementWithContext(ctx, &pixela.PixelIncrementInput{GraphID: f.str("graph")})
+}
+
+func pixelDecrement(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	if err := f.parse(args, "graph"); err != nil {
+		return nil, err
+	}
+	return c.Pixel().DecrementWithContext(ctx, &pixela.PixelDecrementInput{GraphID: f.str("graph")})
+}
+
+func pixelAdd(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("date", "date (yyyyMMdd) (required)")
+	f.stringFlag("quantity", "quantity to add (required)")
+	if err := f.parse(args, "graph", "date", "quantity"); err != nil {
+		return nil, err
+	}
+	return c.Pixel().AddWithContext(ctx, &pixela.PixelAddInput{GraphID: f.str("graph"), Date: f.str("date"), Quantity: f.str("quantity")})
+}
+
+func pixelSubtract(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("date", "date (yyyyMMdd) (required)")
+	f.stringFlag("quantity", "quantity to subtract (required)")
+	if err := f.parse(args, "graph", "date", "quantity"); err != nil {
+		return nil, err
+	}
+	return c.Pixel().SubtractWithContext(ctx, &pixela.PixelSubtractInput{GraphID: f.str("graph"), Date: f.str("date"), Quantity: f.str("quantity")})
+}
+
+func pixelDelete(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("date", "date (yyyyMMdd) (required)")
+	if err := f.parse(args, "graph", "date"); err != nil {
+		return nil, err
+	}
+	return c.Pixel().DeleteWithContext(ctx, &pixela.PixelDeleteInput{GraphID: f.str("graph"), Date: f.str("date")})
+}
+
+func webhookCreate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("type", "increment, decrement, add, subtract or stopwatch (required)")
+	if err := f.parse(args, "graph", "type"); err != nil {
+		return nil, err
+	}
+	return c.Webhook().CreateWithContext(ctx, &pixela.WebhookCreateInput{GraphID: f.str("graph"), Type: f.str("type")})
+}
+
+func webhookList(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	if err := f.parse(args); err != nil {
+		return nil, err
+	}
+	return c.Webhook().GetAllWithContext(ctx)
+}
+
+func webhookInvoke(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("hash", "webhook hash (required)")
+	if err := f.parse(args, "hash"); err != nil {
+		return nil, err
+	}
+	return c.Webhook().InvokeWithContext(ctx, &pixela.WebhookInvokeInput{WebhookHash: f.str("hash")})
+}
+
+func webhookDelete(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("hash", "webhook hash (required)")
+	if err := f.parse(args, "hash"); err != nil {
+		return nil, err
+	}
+	return c.Webhook().DeleteWithContext(ctx, &pixela.WebhookDeleteInput{WebhookHash: f.str("hash")})
+}
+
+func slackFlags(f *flagSet) {
+	f.stringFlag("url", "Incoming Webhook URL of Slack (required)")
+	f.stringFlag("slack-user-name", "user name displayed in Slack (required)")
+	f.stringFlag("slack-channel-name", "Slack channel name (required)")
+}
+
+func slackDetail(f *flagSet) *pixela.SlackDetail {
+	return &pixela.SlackDetail{
+		URL:         f.str("url"),
+		UserName:    f.str("slack-user-name"),
+		ChannelName: f.str("slack-channel-name"),
+	}
+}
+
+func channelCreate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "channel ID (required)")
+	f.stringFlag("name", "channel name (required)")
+	slackFlags(f)
+	if err := f.parse(args, "id", "name", "url", "slack-user-name", "slack-channel-name"); err != nil {
+		return nil, err
+	}
+	return c.Channel().CreateWithContext(ctx, &pixela.ChannelCreateInput{
+		ID:     f.str("id"),
+		Name:   f.str("name"),
+		Type:   pixela.String(pixela.ChannelTypeSlack),
+		Detail: slackDetail(f),
+	})
+}
+
+func channelList(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	if err := f.parse(args); err != nil {
+		return nil, err
+	}
+	return c.Channel().GetAllWithContext(ctx)
+}
+
+func channelUpdate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "channel ID (required)")
+	f.stringFlag("name", "channel name (required)")
+	slackFlags(f)
+	if err := f.parse(args, "id", "name", "url", "slack-user-name", "slack-channel-name"); err != nil {
+		return nil, err
+	}
+	return c.Channel().UpdateWithContext(ctx, &pixela.ChannelUpdateInput{
+		ID:     f.str("id"),
+		Name:   f.str("name"),
+		Type:   pixela.String(pixela.ChannelTypeSlack),
+		Detail: slackDetail(f),
+	})
+}
+
+func channelDelete(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("id", "channel ID (required)")
+	if err := f.parse(args, "id"); err != nil {
+		return nil, err
+	}
+	return c.Channel().DeleteWithContext(ctx, &pixela.ChannelDeleteInput{ID: f.str("id")})
+}
+
+func notificationFlags(f *flagSet) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("id", "notification ID (required)")
+	f.stringFlag("name", "notification name")
+	f.stringFlag("target", "target to be notified: quantity")
+	f.stringFlag("condition", `condition: ">", "=", "<" or "multipleOf"`)
+	f.stringFlag("threshold", "threshold of the condition")
+	f.stringFlag("remind-by", "hour (0-23) to be reminded")
+	f.stringFlag("channel", "channel ID")
+}
+
+func notificationCreate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	notificationFlags(f)
+	if err := f.parse(args, "graph", "id", "name", "condition", "threshold", "channel"); err != nil {
+		return nil, err
+	}
+	target := f.str("target")
+	if target == nil {
+		target = pixela.String(pixela.NotificationTargetQuantity)
+	}
+	return c.Notification().CreateWithContext(ctx, &pixela.NotificationCreateInput{
+		GraphID:   f.str("graph"),
+		ID:        f.str("id"),
+		Name:      f.str("name"),
+		Target:    target,
+		Condition: f.str("condition"),
+		Threshold: f.str("threshold"),
+		RemindBy:  f.str("remind-by"),
+		ChannelID: f.str("channel"),
+	})
+}
+
+func notificationList(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	if err := f.parse(args, "graph"); err != nil {
+		return nil, err
+	}
+	return c.Notification().GetAllWithContext(ctx, &pixela.NotificationGetAllInput{GraphID: f.str("graph")})
+}
+
+func notificationUpdate(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	notificationFlags(f)
+	if err := f.parse(args, "graph", "id"); err != nil {
+		return nil, err
+	}
+	return c.Notification().UpdateWithContext(ctx, &pixela.NotificationUpdateInput{
+		GraphID:   f.str("graph"),
+		ID:        f.str("id"),
+		Name:      f.str("name"),
+		Target:    f.str("target"),
+		Condition: f.str("condition"),
+		Threshold: f.str("threshold"),
+		RemindBy:  f.str("remind-by"),
+		ChannelID: f.str("channel"),
+	})
+}
+
+func notificationDelete(ctx context.Context, c *pixela.Client, f *flagSet, args []string) (any, error) {
+	f.stringFlag("graph", "graph ID (required)")
+	f.stringFlag("id", "notification ID (required)")
+	if err := f.parse(args, "graph", "id"); err != nil {
+		return nil, err
+	}
+	return c.Notification().DeleteWithContext(ctx, &pixela.NotificationDeleteInput{GraphID: f.str("graph"), ID: f.str("id")})
+}
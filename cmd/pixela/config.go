@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+const (
+	envUserName = "PIXELA_USER_NAME"
+	envToken    = "PIXELA_USER_TOKEN"
+	envBaseURL  = "PIXELA_BASE_URL"
+	envConfig   = "PIXELA_CONFIG"
+)
+
+type config struct {
+	UserName string `json:"username"`
+	Token    string `json:"token"`
+	BaseURL  string `json:"baseURL"`
+}
+
+// loadConfig reads the config file and overrides it with the environment variables.
+// A missing config file is not an error unless its path is specified explicitly.
+func loadConfig(path string, getenv func(string) string) (*config, error) {
+	explicit := true
+	if path == "" {
+		path = getenv(envConfig)
+	}
+	if path == "" {
+		explicit = false
+		path = defaultConfigPath()
+	}
+
+	var cfg config
+	if path != "" {
+		b, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(b, &cfg); err != nil {
+				return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+			}
+		case errors.Is(err, fs.ErrNotExist) && !explicit:
+		default:
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	if v := getenv(envUserName); v != "" {
+		cfg.UserName = v
+	}
+	if v := getenv(envToken); v != "" {
+		cfg.Token = v
+	}
+	if v := getenv(envBaseURL); v != "" {
+		cfg.BaseURL = v
+	}
+	if cfg.UserName == "" || cfg.Token == "" {
+		return nil, fmt.Errorf("credentials not found: set %s and %s, or write them in %s", envUserName, envToken, path)
+	}
+	return &cfg, nil
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pixela", "config.json")
+}
+
+func (c *config) newClient() *pixela.Client {
+	opts := []pixela.Option{
+		pixela.WithErrorOnFailure(),
+		pixela.WithRetryPolicy(pixela.RetryPolicy{MaxAttempts: 5, MaxDelay: 5 * time.Second, Jitter: 0.2}),
+	}
+	if c.BaseURL != "" {
+		opts = append(opts, pixela.WithBaseURL(c.BaseURL))
+	}
+	return pixela.New(c.UserName, c.Token, opts...)
+}
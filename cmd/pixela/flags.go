@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+// errInvalidFlags is returned when the flags of a command are invalid.
+// The flag set has already reported the reason and the usage.
+var errInvalidFlags = errors.New("invalid flags")
+
+// A flagSet tells unspecified flags from empty ones,
+// because optional fields of pixela inputs must be nil when they are not specified.
+type flagSet struct {
+	*flag.FlagSet
+	strings map[string]*string
+	bools   map[string]*bool
+}
+
+func newFlagSet(name string, output io.Writer) *flagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: pixela %s [flags]\n", name)
+		fs.PrintDefaults()
+	}
+	return &flagSet{FlagSet: fs, strings: map[string]*string{}, bools: map[string]*bool{}}
+}
+
+func (f *flagSet) stringFlag(name, usage string) {
+	f.strings[name] = f.FlagSet.String(name, "", usage)
+}
+
+func (f *flagSet) boolFlag(name, usage string) {
+	f.bools[name] = f.FlagSet.Bool(name, false, usage)
+}
+
+// parse parses args and checks that the required flags are specified.
+func (f *flagSet) parse(args []string, required ...string) error {
+	if err := f.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errInvalidFlags
+	}
+	if f.NArg() > 0 {
+		fmt.Fprintf(f.Output(), "unexpected arguments: %s\n", strings.Join(f.Args(), " "))
+		f.Usage()
+		return errInvalidFlags
+	}
+	for _, name := range required {
+		if !f.isSet(name) {
+			fmt.Fprintf(f.Output(), "flag is required: -%s\n", name)
+			f.Usage()
+			return errInvalidFlags
+		}
+	}
+	return nil
+}
+
+func (f *flagSet) isSet(name string) bool {
+	set := false
+	f.Visit(func(fl *flag.Flag) {
+		if fl.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// str returns the value of the string flag, or nil if it is not specified.
+func (f *flagSet) str(name string) *string {
+	if !f.isSet(name) {
+		return nil
+	}
+	return pixela.String(*f.strings[name])
+}
+
+// boolean returns the value of the bool flag, or nil if it is not specified.
+func (f *flagSet) boolean(name string) *bool {
+	if !f.isSet(name) {
+		return nil
+	}
+	return pixela.Bool(*f.bools[name])
+}
+
+// list returns the comma-separated values of the string flag, or nil if it is not specified.
+func (f *flagSet) list(name string) []string {
+	if !f.isSet(name) {
+		return nil
+	}
+	return strings.Split(*f.strings[name], ",")
+}
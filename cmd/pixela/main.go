@@ -0,0 +1,106 @@
+// Command pixela is a command-line client for Pixela built on pixela4go.
+//
+// Usage:
+//
+//	pixela [-o table|json] [-config file] <resource> <action> [flags]
+//
+// For example:
+//
+//	pixela graph list
+//	pixela -o json pixel create -graph my-graph -date 20240414 -quantity 5
+//
+// The credentials are read from the environment variables PIXELA_USER_NAME and PIXELA_USER_TOKEN,
+// or from the config file ($XDG_CONFIG_HOME/pixela/config.json by default):
+//
+//	{"username": "your-name", "token": "your-token"}
+//
+// The environment variables take precedence over the config file.
+// PIXELA_BASE_URL (or "baseURL" in the config file) changes the Pixela API endpoint.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv)
+	stop()
+	os.Exit(code)
+}
+
+// run runs the command line args and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	global := flag.NewFlagSet("pixela", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	output := global.String("o", outputTable, "output format: table or json")
+	configPath := global.String("config", "", "path to the config file")
+	global.Usage = func() { usage(global) }
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *output != outputTable && *output != outputJSON {
+		fmt.Fprintf(stderr, "pixela: unknown output format %q\n", *output)
+		return 2
+	}
+
+	rest := global.Args()
+	if len(rest) < 2 {
+		global.Usage()
+		return 2
+	}
+	cmd := findCommand(rest[0], rest[1])
+	if cmd == nil {
+		fmt.Fprintf(stderr, "pixela: unknown command %q\n", rest[0]+" "+rest[1])
+		global.Usage()
+		return 2
+	}
+
+	cfg, err := loadConfig(*configPath, getenv)
+	if err != nil {
+		fmt.Fprintf(stderr, "pixela: %v\n", err)
+		return 1
+	}
+
+	fs := newFlagSet(cmd.name, stderr)
+	v, err := cmd.run(ctx, cfg.newClient(), fs, rest[2:])
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errInvalidFlags):
+		return 2
+	case err != nil:
+		fmt.Fprintf(stderr, "pixela: %v\n", err)
+		return 1
+	}
+
+	if err := writeOutput(stdout, *output, v); err != nil {
+		fmt.Fprintf(stderr, "pixela: failed to write output: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func usage(global *flag.FlagSet) {
+	w := global.Output()
+	fmt.Fprintln(w, "Usage: pixela [-o table|json] [-config file] <resource> <action> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	global.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-22s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `Run "pixela <resource> <action> -h" for the flags of a command.`)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ebc-2in2crc/pixela4go/pixelatest"
+)
+
+func newEnv(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func newTestEnv(t *testing.T) func(string) string {
+	t.Helper()
+	srv := pixelatest.NewServer()
+	t.Cleanup(srv.Close)
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return newEnv(map[string]string{
+		envUserName: "pixela-cli",
+		envToken:    "thisissecret",
+		envBaseURL:  srv.URL,
+		envConfig:   path,
+	})
+}
+
+func runCLI(t *testing.T, getenv func(string) string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr, getenv)
+	return code, stdout.String(), stderr.String()
+}
+
+func mustRun(t *testing.T, getenv func(string) string, args ...string) string {
+	t.Helper()
+	code, stdout, stderr := runCLI(t, getenv, args...)
+	if code != 0 {
+		t.Fatalf("%s: got: %d (%s)\nwant: 0", strings.Join(args, " "), code, stderr)
+	}
+	return stdout
+}
+
+func TestRun(t *testing.T) {
+	getenv := newTestEnv(t)
+
+	mustRun(t, getenv, "user", "create", "-agree-terms-of-service", "-not-minor")
+	mustRun(t, getenv, "graph", "create", "-id", "test-graph", "-name", "graph-name", "-unit", "times", "-type", "int", "-color", "shibafu")
+	mustRun(t, getenv, "pixel", "create", "-graph", "test-graph", "-date", "20220101", "-quantity", "5")
+	mustRun(t, getenv, "pixel", "create", "-graph", "test-graph", "-date", "20240101", "-quantity", "3")
+	mustRun(t, getenv, "pixel", "add", "-graph", "test-graph", "-date", "20240101", "-quantity", "2")
+
+	stdout := mustRun(t, getenv, "pixel", "get", "-graph", "test-graph", "-date", "20240101")
+	if strings.Contains(stdout, "QUANTITY") == false || strings.Contains(stdout, "5") == false {
+		t.Errorf("got: %s\nwant: QUANTITY 5", stdout)
+	}
+
+	stdout = mustRun(t, getenv, "graph", "list")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || strings.HasPrefix(lines[0], "ID") == false || strings.HasPrefix(lines[1], "test-graph") == false {
+		t.Errorf("got: %s\nwant: a header and test-graph", stdout)
+	}
+
+	stdout = mustRun(t, getenv, "-o", "json", "graph", "pixels", "-id", "test-graph", "-from", "20210101", "-to", "20241231", "-dates-only")
+	if strings.Contains(stdout, `"pixels": [`) == false || strings.Contains(stdout, `"20220101"`) == false || strings.Contains(stdout, `"20240101"`) == false {
+		t.Errorf("got: %s\nwant: pixels of 20220101 and 20240101", stdout)
+	}
+
+	stdout = mustRun(t, getenv, "graph", "svg", "-id", "test-graph")
+	if strings.HasPrefix(stdout, "<svg") == false {
+		t.Errorf("got: %s\nwant: <svg ...", stdout)
+	}
+
+	stdout = mustRun(t, getenv, "graph", "delete", "-id", "test-graph")
+	if stdout != "Success.\n" {
+		t.Errorf("got: %s\nwant: Success.", stdout)
+	}
+}
+
+func TestRun_Fail(t *testing.T) {
+	getenv := newTestEnv(t)
+	mustRun(t, getenv, "user", "create", "-agree-terms-of-service", "-not-minor")
+
+	code, _, stderr := runCLI(t, getenv, "graph", "get", "-id", "unknown")
+	if code != 1 || strings.Contains(stderr, "404") == false {
+		t.Errorf("got: %d %s\nwant: 1 404", code, stderr)
+	}
+
+	code, _, stderr = runCLI(t, getenv, "graph", "get")
+	if code != 2 || strings.Contains(stderr, "-id") == false {
+		t.Errorf("got: %d %s\nwant: 2 -id", code, stderr)
+	}
+
+	code, _, _ = runCLI(t, getenv, "graph", "unknown")
+	if code != 2 {
+		t.Errorf("got: %d\nwant: 2", code)
+	}
+
+	code, _, _ = runCLI(t, getenv, "-o", "yaml", "graph", "list")
+	if code != 2 {
+		t.Errorf("got: %d\nwant: 2", code)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"username":"file-user","token":"file-token","baseURL":"http://localhost"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(path, newEnv(nil))
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := config{UserName: "file-user", Token: "file-token", BaseURL: "http://localhost"}
+	if *cfg != expect {
+		t.Errorf("got: %+v\nwant: %+v", cfg, expect)
+	}
+
+	cfg, err = loadConfig(path, newEnv(map[string]string{envToken: "env-token"}))
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if cfg.UserName != "file-user" || cfg.Token != "env-token" {
+		t.Errorf("got: %+v\nwant: file-user env-token", cfg)
+	}
+
+	if _, err := loadConfig(filepath.Join(t.TempDir(), "missing.json"), newEnv(nil)); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+	if _, err := loadConfig("", newEnv(map[string]string{envConfig: filepath.Join(t.TempDir(), "missing.json"), envUserName: "user"})); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+}
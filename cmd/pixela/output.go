@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// writeOutput writes the result of a command in the format.
+// Strings such as SVG and URLs are written as they are in either format.
+func writeOutput(w io.Writer, format string, v any) error {
+	if s, ok := v.(string); ok {
+		if !strings.HasSuffix(s, "\n") {
+			s += "\n"
+		}
+		_, err := io.WriteString(w, s)
+		return err
+	}
+
+	if format == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	return writeTable(w, v)
+}
+
+// writeTable writes a list result (e.g. GraphDefinitions) as rows with a header,
+// a Result as its message, and any other result as name and value pairs.
+func writeTable(w io.Writer, v any) error {
+	if r, ok := v.(*pixela.Result); ok {
+		_, err := fmt.Fprintln(w, r.Message)
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	fields := ownFields(rv.Type())
+	if len(fields) == 1 && fields[0].Type.Kind() == reflect.Slice {
+		writeRows(tw, fields[0].Name, rv.FieldByIndex(fields[0].Index))
+	} else {
+		for _, f := range fields {
+			fmt.Fprintf(tw, "%s\t%s\n", columnName(f.Name), formatValue(rv.FieldByIndex(f.Index)))
+		}
+	}
+	return tw.Flush()
+}
+
+func writeRows(w io.Writer, name string, list reflect.Value) {
+	elem := list.Type().Elem()
+	if elem.Kind() != reflect.Struct {
+		fmt.Fprintln(w, columnName(name))
+		for i := 0; i < list.Len(); i++ {
+			fmt.Fprintln(w, formatValue(list.Index(i)))
+		}
+		return
+	}
+
+	fields := ownFields(elem)
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = columnName(f.Name)
+	}
+	fmt.Fprintln(w, strings.Join(columns, "\t"))
+	for i := 0; i < list.Len(); i++ {
+		values := make([]string, len(fields))
+		for j, f := range fields {
+			values[j] = formatValue(list.Index(i).FieldByIndex(f.Index))
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+}
+
+// ownFields returns the exported fields of t except embedded ones such as pixela.Result.
+func ownFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for _, f := range reflect.VisibleFields(t) {
+		if f.IsExported() && !f.Anonymous && len(f.Index) == 1 {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice:
+		s := make([]string, v.Len())
+		for i := range s {
+			s[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(s, ",")
+	case reflect.Struct:
+		fields := ownFields(v.Type())
+		s := make([]string, len(fields))
+		for i, f := range fields {
+			s[i] = f.Name + "=" + formatValue(v.FieldByIndex(f.Index))
+		}
+		return strings.Join(s, " ")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// columnName converts a field name such as PurgeCacheURLs to PURGE_CACHE_URLS.
+func columnName(name string) string {
+	var b strings.Builder
+	r := []rune(name)
+	for i, c := range r {
+		if i > 0 && unicode.IsUpper(c) && unicode.IsLower(r[i-1]) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,405 @@
+package pixela
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVColumns are the header names of the columns of pixel CSV files.
+type CSVColumns struct {
+	Date     string
+	Quantity string
+	// OptionalData is the header name of the optional data column.
+	// If it is empty, optional data is neither exported nor imported.
+	OptionalData string
+}
+
+// DefaultCSVColumns are the columns used unless Columns is specified.
+var DefaultCSVColumns = CSVColumns{Date: "date", Quantity: "quantity", OptionalData: "optionalData"}
+
+// Specify how to import a pixel of a date that already has a pixel.
+const (
+	// CSVConflictSkip keeps the existing pixel.
+	CSVConflictSkip = "skip"
+	// CSVConflictOverwrite replaces the existing pixel.
+	CSVConflictOverwrite = "overwrite"
+	// CSVConflictAdd adds the quantity to the existing quantity.
+	CSVConflictAdd = "add"
+)
+
+// Actions of PixelChange.
+const (
+	PixelChangeCreate    = "create"
+	PixelChangeUpdate    = "update"
+	PixelChangeSkip      = "skip"
+	PixelChangeUnchanged = "unchanged"
+)
+
+// ExportCSV writes the pixels of the graph from From to To to w in CSV with a header row.
+// Without From and To, it exports the full pixel history of the graph.
+func (g *Graph) ExportCSV(ctx context.Context, w io.Writer, input *GraphExportCSVInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	def, err := g.GetWithContext(ctx, &GraphGetInput{ID: input.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get graph: %w", err)
+	}
+	if !def.IsSuccess {
+		param := g.createGetRequestParameter(&GraphGetInput{ID: input.ID})
+		return fmt.Errorf("failed to get graph: %w", newResultError(param, &def.Result))
+	}
+	columns := csvColumns(input.Columns)
+	layout := csvDateLayout(input.DateFormat)
+
+	cw := csv.NewWriter(w)
+	header := []string{columns.Date, columns.Quantity}
+	if columns.OptionalData != "" {
+		header = append(header, columns.OptionalData)
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	for p, err := range g.pixelsOf(ctx, def, input.From, input.To, input.Concurrency) {
+		if err != nil {
+			return fmt.Errorf("failed to get pixels: %w", err)
+		}
+		t, err := p.Time()
+		if err != nil {
+			return fmt.Errorf("invalid pixel date: %w", err)
+		}
+		record := []string{t.Format(layout), p.Quantity}
+		if columns.OptionalData != "" {
+			record = append(record, p.OptionalData)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// GraphExportCSVInput is input of Graph.ExportCSV().
+type GraphExportCSVInput struct {
+	// ID is a required field
+	ID *string
+	// From is the first date in yyyyMMdd format (default: the first pixel).
+	From *string
+	// To is the last date in yyyyMMdd format (default: the last pixel, even after today).
+	To *string
+	// DateFormat is the layout of dates in the CSV such as "2006-01-02" (default: "20060102").
+	DateFormat string
+	// Columns are the header names (default: DefaultCSVColumns).
+	Columns *CSVColumns
+	// Concurrency is the number of requests sent at a time (default: 1).
+	Concurrency int
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphExportCSVInput) Validate() error {
+	v := newValidator("GraphExportCSVInput")
+	v.field("ID", in.ID).required().id()
+	v.field("From", in.From).date()
+	v.field("To", in.To).date()
+	validateCSVColumns(v, in.Columns)
+	return v.err()
+}
+
+// ImportCSV imports the pixels in the CSV read from r into the graph.
+// The CSV must have a header row with the columns. The pixels are compared with the existing pixels,
+// and the pixels to create or update are written with a BulkWriter unless DryRun is true.
+// If the CSV has invalid rows, ImportCSV returns a *ValidationError listing them without writing any pixel.
+func (g *Graph) ImportCSV(ctx context.Context, r io.Reader, input *GraphImportCSVInput) (*CSVImportResult, error) {
+	if err := input.Validate(); err != nil {
+		return &CSVImportResult{}, err
+	}
+
+	def, err := g.GetWithContext(ctx, &GraphGetInput{ID: input.ID})
+	if err != nil {
+		return &CSVImportResult{}, fmt.Errorf("failed to get graph: %w", err)
+	}
+	if !def.IsSuccess {
+		param := g.createGetRequestParameter(&GraphGetInput{ID: input.ID})
+		return &CSVImportResult{}, fmt.Errorf("failed to get graph: %w", newResultError(param, &def.Result))
+	}
+
+	pixels, err := readPixelsCSV(r, csvColumns(input.Columns), csvDateLayout(input.DateFormat), def.Type)
+	if err != nil {
+		return &CSVImportResult{}, err
+	}
+	if len(pixels) == 0 {
+		return &CSVImportResult{}, nil
+	}
+
+	from, to := StringValue(pixels[0].Date), StringValue(pixels[len(pixels)-1].Date)
+	existing := map[string]PixelWithBody{}
+	for p, err := range g.AllPixels(ctx, &GraphAllPixelsInput{ID: input.ID, From: &from, To: &to, Concurrency: input.Concurrency}) {
+		if err != nil {
+			return &CSVImportResult{}, fmt.Errorf("failed to get existing pixels: %w", err)
+		}
+		existing[p.Date] = p
+	}
+
+	conflict := input.Conflict
+	if conflict == "" {
+		conflict = CSVConflictSkip
+	}
+	result := &CSVImportResult{}
+	for _, p := range pixels {
+		change, err := newPixelChange(p, existing, conflict, def.Type)
+		if err != nil {
+			return &CSVImportResult{}, err
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	if input.DryRun {
+		return result, nil
+	}
+
+	writer := g.BulkWriter(&BulkWriterInput{GraphID: input.ID, GraphType: String(def.Type), Concurrency: input.Concurrency, Progress: input.Progress})
+	result.Report, err = writer.Write(ctx, result.pixelsToWrite())
+	return result, err
+}
+
+// GraphImportCSVInput is input of Graph.ImportCSV().
+type GraphImportCSVInput struct {
+	// ID is a required field
+	ID *string
+	// DateFormat is the layout of dates in the CSV such as "2006-01-02" (default: "20060102").
+	DateFormat string
+	// Columns are the header names (default: DefaultCSVColumns).
+	Columns *CSVColumns
+	// Conflict is how to import a pixel of a date that already has a pixel (default: CSVConflictSkip).
+	Conflict string
+	// DryRun makes ImportCSV only compare the pixels without writing them.
+	DryRun bool
+	// Concurrency is the number of requests sent at a time (default: 1).
+	Concurrency int
+	// Progress is called with the progress of writing the pixels.
+	Progress func(BulkProgress)
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphImportCSVInput) Validate() error {
+	v := newValidator("GraphImportCSVInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Conflict", &in.Conflict).oneOf(CSVConflictSkip, CSVConflictOverwrite, CSVConflictAdd)
+	validateCSVColumns(v, in.Columns)
+	return v.err()
+}
+
+// CSVImportResult is the result of Graph.ImportCSV().
+type CSVImportResult struct {
+	// Changes are the changes of the pixels in date order.
+	Changes []PixelChange
+	// Report is the report of writing the pixels. It is nil if DryRun is true.
+	Report *BulkReport
+}
+
+// PixelChange is the change of the pixel of a date by an import.
+type PixelChange struct {
+	// Date is in yyyyMMdd format.
+	Date string
+	// Action is one of PixelChangeCreate, PixelChangeUpdate, PixelChangeSkip and PixelChangeUnchanged.
+	Action string
+	// Old is the existing pixel, or nil if there is none.
+	Old *PixelWithBody
+	// New is the pixel to write. It is the existing pixel if Action is PixelChangeSkip or PixelChangeUnchanged.
+	New PixelInput
+}
+
+func (r *CSVImportResult) pixelsToWrite() iter.Seq[PixelInput] {
+	return func(yield func(PixelInput) bool) {
+		for _, c := range r.Changes {
+			if c.Action != PixelChangeCreate && c.Action != PixelChangeUpdate {
+				continue
+			}
+			if !yield(c.New) {
+				return
+			}
+		}
+	}
+}
+
+func newPixelChange(p PixelInput, existing map[string]PixelWithBody, conflict, graphType string) (PixelChange, error) {
+	date := StringValue(p.Date)
+	old, ok := existing[date]
+	if !ok {
+		return PixelChange{Date: date, Action: PixelChangeCreate, New: p}, nil
+	}
+
+	change := PixelChange{Date: date, Old: &old, New: p}
+	switch conflict {
+	case CSVConflictSkip:
+		change.Action = PixelChangeSkip
+	case CSVConflictAdd:
+		quantity, err := addQuantities(graphType, old.Quantity, StringValue(p.Quantity))
+		if err != nil {
+			return PixelChange{}, fmt.Errorf("failed to add quantity of %s: %w", date, err)
+		}
+		change.New.Quantity = String(quantity)
+		if p.OptionalData == nil {
+			change.New.OptionalData = String(old.OptionalData)
+		}
+		change.Action = PixelChangeUpdate
+	default:
+		change.Action = PixelChangeUpdate
+	}
+
+	if change.Action == PixelChangeSkip {
+		change.New = PixelInput{Date: p.Date, Quantity: String(old.Quantity), OptionalData: String(old.OptionalData)}
+	} else if StringValue(change.New.Quantity) == old.Quantity && StringValue(change.New.OptionalData) == old.OptionalData {
+		change.Action = PixelChangeUnchanged
+	}
+	return change, nil
+}
+
+// readPixelsCSV reads the pixels in date order, and returns a *ValidationError listing invalid rows.
+func readPixelsCSV(r io.Reader, columns CSVColumns, layout, graphType string) ([]PixelInput, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+
+	v := newValidator("CSV")
+	dateIndex := slices.Index(header, columns.Date)
+	quantityIndex := slices.Index(header, columns.Quantity)
+	optionalDataIndex := -1
+	if columns.OptionalData != "" {
+		optionalDataIndex = slices.Index(header, columns.OptionalData)
+	}
+	if dateIndex < 0 {
+		v.add("header", "must have the %s column", columns.Date)
+	}
+	if quantityIndex < 0 {
+		v.add("header", "must have the %s column", columns.Quantity)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	var pixels []PixelInput
+	lines := map[string]int{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		field := fmt.Sprintf("line %d", line)
+		if len(record) <= max(dateIndex, quantityIndex, optionalDataIndex) {
+			v.add(field, "must have %d columns", len(header))
+			continue
+		}
+		t, err := time.Parse(layout, strings.TrimSpace(record[dateIndex]))
+		if err != nil {
+			v.add(field, "has an invalid date %q", record[dateIndex])
+			continue
+		}
+		date := t.Format(dateLayout)
+		if prev, ok := lines[date]; ok {
+			v.add(field, "has the same date as line %d", prev)
+			continue
+		}
+		lines[date] = line
+
+		quantity := strings.TrimSpace(record[quantityIndex])
+		if err := ValidateQuantity(graphType, quantity); err != nil {
+			v.add(field, "has an invalid quantity %q for %s graphs", quantity, graphType)
+			continue
+		}
+		p := PixelInput{Date: String(date), Quantity: String(quantity)}
+		if optionalDataIndex >= 0 && record[optionalDataIndex] != "" {
+			p.OptionalData = String(record[optionalDataIndex])
+		}
+		pixels = append(pixels, p)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(pixels, func(a, b PixelInput) int {
+		return strings.Compare(*a.Date, *b.Date)
+	})
+	return pixels, nil
+}
+
+// addQuantities adds quantities of the graph type without rounding errors of floats.
+func addQuantities(graphType, a, b string) (string, error) {
+	if graphType == GraphTypeInt {
+		x, err := parseIntQuantity(a)
+		if err != nil {
+			return "", err
+		}
+		y, err := parseIntQuantity(b)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(x+y, 10), nil
+	}
+
+	x, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return "", fmt.Errorf("invalid quantity: %s", a)
+	}
+	y, ok := new(big.Rat).SetString(b)
+	if !ok {
+		return "", fmt.Errorf("invalid quantity: %s", b)
+	}
+	return x.Add(x, y).FloatString(max(decimals(a), decimals(b))), nil
+}
+
+// decimals returns the number of digits after the decimal point of the quantity.
+func decimals(quantity string) int {
+	if i := strings.IndexByte(quantity, '.'); i >= 0 {
+		return len(quantity) - i - 1
+	}
+	return 0
+}
+
+func csvColumns(columns *CSVColumns) CSVColumns {
+	if columns == nil {
+		return DefaultCSVColumns
+	}
+	return *columns
+}
+
+func csvDateLayout(format string) string {
+	if format == "" {
+		return dateLayout
+	}
+	return format
+}
+
+func validateCSVColumns(v *validator, columns *CSVColumns) {
+	if columns == nil {
+		return
+	}
+	v.field("Columns.Date", &columns.Date).required()
+	v.field("Columns.Quantity", &columns.Quantity).required()
+}
@@ -0,0 +1,154 @@
+package pixela
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraph_ExportCSV(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	pixels := []*PixelCreateInput{
+		{GraphID: String(graphID), Date: String("20240102"), Quantity: String("2"), OptionalData: String(`{"note":"a, b"}`)},
+		{GraphID: String(graphID), Date: String("20240101"), Quantity: String("1")},
+	}
+	for _, p := range pixels {
+		if _, err := client.Pixel().Create(p); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	input := &GraphExportCSVInput{ID: String(graphID), From: String("20231201"), To: String("20240131"), DateFormat: "2006-01-02"}
+	if err := client.Graph().ExportCSV(context.Background(), &buf, input); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := "date,quantity,optionalData\n" +
+		"2024-01-01,1,\n" +
+		"2024-01-02,2,\"{\"\"note\"\":\"\"a, b\"\"}\"\n"
+	if buf.String() != expect {
+		t.Errorf("got: %s\nwant: %s", buf.String(), expect)
+	}
+}
+
+func TestGraph_ExportCSVFullHistory(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	future := time.Now().AddDate(0, 0, 10).Format(dateLayout)
+	for _, date := range []string{"19990101", "20240101", future} {
+		if _, err := client.Pixel().Create(&PixelCreateInput{GraphID: String(graphID), Date: String(date), Quantity: String("1")}); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	input := &GraphExportCSVInput{ID: String(graphID), Columns: &CSVColumns{Date: "date", Quantity: "quantity"}}
+	if err := client.Graph().ExportCSV(context.Background(), &buf, input); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := "date,quantity\n19990101,1\n20240101,1\n" + future + ",1\n"
+	if buf.String() != expect {
+		t.Errorf("got: %s\nwant: %s", buf.String(), expect)
+	}
+}
+
+func TestGraph_ImportCSV(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	if _, err := client.Pixel().Create(&PixelCreateInput{GraphID: String(graphID), Date: String("20240101"), Quantity: String("5")}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	csv := "day,count,note\n2024-01-02,7,memo\n2024-01-01,3,\n"
+	columns := &CSVColumns{Date: "day", Quantity: "count", OptionalData: "note"}
+
+	params := []struct {
+		conflict string
+		expect   []string
+	}{
+		{conflict: CSVConflictSkip, expect: []string{PixelChangeSkip + " 5", PixelChangeCreate + " 7"}},
+		{conflict: CSVConflictOverwrite, expect: []string{PixelChangeUpdate + " 3", PixelChangeCreate + " 7"}},
+		{conflict: CSVConflictAdd, expect: []string{PixelChangeUpdate + " 8", PixelChangeCreate + " 7"}},
+	}
+	for _, p := range params {
+		input := &GraphImportCSVInput{ID: String(graphID), DateFormat: "2006-01-02", Columns: columns, Conflict: p.conflict, DryRun: true}
+		result, err := client.Graph().ImportCSV(context.Background(), strings.NewReader(csv), input)
+		if err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+		var got []string
+		for _, c := range result.Changes {
+			got = append(got, c.Action+" "+StringValue(c.New.Quantity))
+		}
+		if !reflect.DeepEqual(got, p.expect) {
+			t.Errorf("%s: got: %v\nwant: %v", p.conflict, got, p.expect)
+		}
+		if result.Report != nil {
+			t.Errorf("%s: got: %v\nwant: nil", p.conflict, result.Report)
+		}
+	}
+	if _, ok := srv.Quantity(userName, graphID, "20240102"); ok {
+		t.Errorf("got: a pixel written by a dry run\nwant: none")
+	}
+
+	input := &GraphImportCSVInput{ID: String(graphID), DateFormat: "2006-01-02", Columns: columns, Conflict: CSVConflictAdd}
+	result, err := client.Graph().ImportCSV(context.Background(), strings.NewReader(csv), input)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if result.Report.Written != 2 {
+		t.Errorf("got: %d\nwant: %d", result.Report.Written, 2)
+	}
+	for date, expect := range map[string]string{"20240101": "8", "20240102": "7"} {
+		if q, _ := srv.Quantity(userName, graphID, date); q != expect {
+			t.Errorf("got: %s\nwant: %s", q, expect)
+		}
+	}
+
+	result, err = client.Graph().ImportCSV(context.Background(), strings.NewReader("day,count,note\n2024-01-02,7,memo\n"), input)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if result.Changes[0].Action != PixelChangeUpdate || result.Report.Written != 1 {
+		t.Errorf("got: %v, %+v\nwant: an update written", result.Changes[0].Action, result.Report.BulkProgress)
+	}
+}
+
+func TestGraph_ImportCSVInvalidRows(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	csv := "date,quantity\n20240101,1.5\n2024-01-02,1\n20240101,2\n20240103\n"
+	_, err := client.Graph().ImportCSV(context.Background(), strings.NewReader(csv), &GraphImportCSVInput{ID: String(graphID)})
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) == false {
+		t.Fatalf("got: %v\nwant: *ValidationError", err)
+	}
+	expect := []FieldProblem{
+		{Field: "line 2", Message: `has an invalid quantity "1.5" for int graphs`},
+		{Field: "line 3", Message: `has an invalid date "2024-01-02"`},
+		{Field: "line 4", Message: "has the same date as line 2"},
+		{Field: "line 5", Message: "must have 2 columns"},
+	}
+	if !reflect.DeepEqual(validationErr.Problems, expect) {
+		t.Errorf("got: %v\nwant: %v", validationErr.Problems, expect)
+	}
+}
+
+func TestAddQuantities(t *testing.T) {
+	params := []struct {
+		graphType string
+		a, b      string
+		expect    string
+	}{
+		{graphType: GraphTypeInt, a: "5", b: "-7", expect: "-2"},
+		{graphType: GraphTypeFloat, a: "0.1", b: "0.2", expect: "0.3"},
+		{graphType: GraphTypeFloat, a: "1.25", b: "2", expect: "3.25"},
+	}
+	for _, p := range params {
+		got, err := addQuantities(p.graphType, p.a, p.b)
+		if err != nil || got != p.expect {
+			t.Errorf("got: %s, %v\nwant: %s", got, err, p.expect)
+		}
+	}
+}
@@ -70,6 +70,7 @@ func testE2EGraphGetAll(t *testing.T) {
 			Color:          GraphColorShibafu,
 			TimeZone:       "Asia/Tokyo",
 			SelfSufficient: GraphSelfSufficientIncrement,
+			StartOnMonday:  true,
 		},
 	}
 	if reflect.DeepEqual(result.Graphs, expected) == false {
@@ -97,6 +98,7 @@ func testE2EGraphGet(t *testing.T) {
 		Color:          GraphColorShibafu,
 		TimeZone:       "Asia/Tokyo",
 		SelfSufficient: GraphSelfSufficientIncrement,
+		StartOnMonday:  true,
 		Result: Result{
 			IsSuccess:  true,
 			Message:    "",
@@ -154,6 +156,38 @@ func testE2EGraphGetPixelDates(t *testing.T) {
 	}
 }
 
+func testE2EGraphGetPixelDateList(t *testing.T) {
+	input := &GraphGetPixelDatesInput{
+		ID: String(graphID),
+	}
+	result, err := e2eClient.Graph().GetPixelDateList(input)
+	if err != nil {
+		t.Errorf("Graph.GetPixelDateList() got: %+v\nwant: nil", err)
+	}
+	if result.IsSuccess == false {
+		t.Errorf("Graph.GetPixelDateList() got: %+v\nwant: true", result)
+	}
+	if len(result.Dates) != 1 {
+		t.Errorf("Graph.GetPixelDateList() got: %+v\nwant: 1", len(result.Dates))
+	}
+}
+
+func testE2EGraphGetPixelsWithBody(t *testing.T) {
+	input := &GraphGetPixelDatesInput{
+		ID: String(graphID),
+	}
+	result, err := e2eClient.Graph().GetPixelsWithBody(input)
+	if err != nil {
+		t.Errorf("Graph.GetPixelsWithBody() got: %+v\nwant: nil", err)
+	}
+	if result.IsSuccess == false {
+		t.Errorf("Graph.GetPixelsWithBody() got: %+v\nwant: true", result)
+	}
+	if len(result.Pixels) != 1 {
+		t.Errorf("Graph.GetPixelsWithBody() got: %+v\nwant: 1", len(result.Pixels))
+	}
+}
+
 func testE2EGraphGetToday(t *testing.T) {
 	// Test with ReturnEmpty = nil (default behavior)
 	input1 := &GraphGetTodayInput{
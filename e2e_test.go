@@ -1,28 +1,16 @@
 package pixela
 
 import (
-	"fmt"
 	"os"
 	"testing"
+
+	"github.com/ebc-2in2crc/pixela4go/pixelatest"
 )
 
 var e2eClient *Client
 
 func TestE2E(t *testing.T) {
-	if os.Getenv("PIXELA4GO_E2E_TEST_RUN") == "" {
-		msg := `E2E test skip.
-If you run E2E test, Set below environment variables.
-
-- PIXELA4GO_E2E_TEST_RUN=ON
-- PIXELA4GO_THANKS_CODE=<pixela-thanks-code-for-testing>
-- PIXELA4GO_USER_NAME=<pixela-username-for-testing>
-- PIXELA4GO_USER_FIRST_TOKEN=<pixela-user-token-for-testing>
-- PIXELA4GO_USER_SECOND_TOKEN=<pixela-user-token-for-testing>`
-		fmt.Println(msg)
-		return
-	}
-
-	initE2ETest()
+	initE2ETest(t)
 
 	testE2EUserCreate(t)
 	testE2EUserUpdate(t)
@@ -35,6 +23,8 @@ If you run E2E test, Set below environment variables.
 	testE2EGraphGet(t)
 	testE2EGraphStopwatch(t)
 	testE2EGraphGetPixelDates(t)
+	testE2EGraphGetPixelDateList(t)
+	testE2EGraphGetPixelsWithBody(t)
 	testE2EGraphGetToday(t)
 	testE2EGraphGetSVG(t)
 	testE2EGraphStats(t)
@@ -58,10 +48,30 @@ If you run E2E test, Set below environment variables.
 	testE2EUserDelete(t)
 }
 
-func initE2ETest() {
-	RetryCount = 20
+// initE2ETest runs E2E test against pixe.la if PIXELA4GO_E2E_TEST_RUN is set.
+// Otherwise, it runs E2E test against the in-memory fake server (pixelatest).
+//
+// If you run E2E test against pixe.la, Set below environment variables.
+//
+// - PIXELA4GO_E2E_TEST_RUN=ON
+// - PIXELA4GO_THANKS_CODE=<pixela-thanks-code-for-testing>
+// - PIXELA4GO_USER_NAME=<pixela-username-for-testing>
+// - PIXELA4GO_USER_FIRST_TOKEN=<pixela-user-token-for-testing>
+// - PIXELA4GO_USER_SECOND_TOKEN=<pixela-user-token-for-testing>
+func initE2ETest(t *testing.T) {
+	opts := []Option{WithRetryPolicy(RetryPolicy{MaxAttempts: maxRetryCount + 1})}
+	if os.Getenv("PIXELA4GO_E2E_TEST_RUN") == "" {
+		srv := pixelatest.NewServer()
+		t.Cleanup(srv.Close)
+
+		t.Setenv("PIXELA4GO_THANKS_CODE", "")
+		t.Setenv("PIXELA4GO_USER_NAME", "pixela4go-e2e")
+		t.Setenv("PIXELA4GO_USER_FIRST_TOKEN", "first-token")
+		t.Setenv("PIXELA4GO_USER_SECOND_TOKEN", "second-token")
+		opts = append(opts, WithBaseURL(srv.URL))
+	}
 
 	name := os.Getenv("PIXELA4GO_USER_NAME")
 	token := os.Getenv("PIXELA4GO_USER_FIRST_TOKEN")
-	e2eClient = New(name, token)
+	e2eClient = New(name, token, opts...)
 }
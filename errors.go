@@ -0,0 +1,110 @@
+package pixela
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for Pixela API failures.
+// They can be compared with an *APIError by errors.Is.
+var (
+	// ErrBadRequest is matched by an APIError with status code 400.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized is matched by an APIError with status code 401 or 403.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound is matched by an APIError with status code 404.
+	ErrNotFound = errors.New("not found")
+	// ErrUserNotFound is matched by an APIError reporting that the user is not found.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrGraphNotFound is matched by an APIError reporting that the graph is not found.
+	ErrGraphNotFound = errors.New("graph not found")
+	// ErrPixelNotFound is matched by an APIError reporting that the pixel is not found.
+	ErrPixelNotFound = errors.New("pixel not found")
+	// ErrWebhookNotFound is matched by an APIError reporting that the webhook is not found.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrChannelNotFound is matched by an APIError reporting that the channel is not found.
+	ErrChannelNotFound = errors.New("channel not found")
+	// ErrNotificationNotFound is matched by an APIError reporting that the notification is not found.
+	ErrNotificationNotFound = errors.New("notification not found")
+	// ErrConflict is matched by an APIError with status code 409.
+	ErrConflict = errors.New("conflict")
+)
+
+// APIError is a failed Pixela API call.
+type APIError struct {
+	StatusCode int
+	Message    string
+	IsRejected bool
+	Method     string
+	URL        string
+}
+
+func newAPIError(param *requestParameter, statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Method:     param.Method,
+		URL:        param.URL,
+	}
+
+	r, err := parseNormalResponse(body)
+	if err != nil {
+		apiErr.Message = strings.TrimSpace(string(body))
+		return apiErr
+	}
+	apiErr.Message = r.Message
+	apiErr.IsRejected = r.IsRejected
+	return apiErr
+}
+
+// newResultError returns the APIError of the failed result of the request.
+func newResultError(param *requestParameter, r *Result) *APIError {
+	return &APIError{
+		StatusCode: r.StatusCode,
+		Message:    r.Message,
+		IsRejected: r.IsRejected,
+		Method:     param.Method,
+		URL:        param.URL,
+	}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: %d: %s", e.Method, e.URL, e.StatusCode, e.Message)
+}
+
+// Is reports whether the APIError matches target.
+// The sentinel errors in this package and ErrAPICallRejected are supported.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrAPICallRejected:
+		return e.IsRejected
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUserNotFound:
+		return e.notFound("user")
+	case ErrGraphNotFound:
+		return e.notFound("graph")
+	case ErrPixelNotFound:
+		return e.notFound("pixel")
+	case ErrWebhookNotFound:
+		return e.notFound("webhook")
+	case ErrChannelNotFound:
+		return e.notFound("channel")
+	case ErrNotificationNotFound:
+		return e.notFound("notification")
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// notFound reports whether the APIError is "Specified <resource> not found.".
+func (e *APIError) notFound(resource string) bool {
+	return e.StatusCode == http.StatusNotFound &&
+		strings.Contains(strings.ToLower(e.Message), resource+" not found")
+}
@@ -0,0 +1,107 @@
+package pixela
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	params := []struct {
+		err    *APIError
+		target error
+		expect bool
+	}{
+		{err: &APIError{StatusCode: http.StatusBadRequest}, target: ErrBadRequest, expect: true},
+		{err: &APIError{StatusCode: http.StatusUnauthorized}, target: ErrUnauthorized, expect: true},
+		{err: &APIError{StatusCode: http.StatusForbidden}, target: ErrUnauthorized, expect: true},
+		{err: &APIError{StatusCode: http.StatusNotFound}, target: ErrNotFound, expect: true},
+		{err: &APIError{StatusCode: http.StatusNotFound, Message: "Specified graph not found."}, target: ErrGraphNotFound, expect: true},
+		{err: &APIError{StatusCode: http.StatusNotFound, Message: "Specified graph not found."}, target: ErrPixelNotFound, expect: false},
+		{err: &APIError{StatusCode: http.StatusNotFound, Message: "Specified pixel not found."}, target: ErrPixelNotFound, expect: true},
+		{err: &APIError{StatusCode: http.StatusNotFound, Message: "Specified user not found."}, target: ErrUserNotFound, expect: true},
+		{err: &APIError{StatusCode: http.StatusNotFound, Message: "Specified webhook not found."}, target: ErrWebhookNotFound, expect: true},
+		{err: &APIError{StatusCode: http.StatusNotFound, Message: "Specified channel not found."}, target: ErrChannelNotFound, expect: true},
+		{err: &APIError{StatusCode: http.StatusNotFound, Message: "Specified notification not found."}, target: ErrNotificationNotFound, expect: true},
+		{err: &APIError{StatusCode: http.StatusConflict}, target: ErrConflict, expect: true},
+		{err: &APIError{StatusCode: http.StatusServiceUnavailable, IsRejected: true}, target: ErrAPICallRejected, expect: true},
+		{err: &APIError{StatusCode: http.StatusServiceUnavailable}, target: ErrAPICallRejected, expect: false},
+		{err: &APIError{StatusCode: http.StatusBadRequest}, target: ErrNotFound, expect: false},
+	}
+
+	for _, p := range params {
+		err := fmt.Errorf("wrapped: %w", p.err)
+		if errors.Is(err, p.target) != p.expect {
+			t.Errorf("errors.Is(%v, %v) got: %v\nwant: %v", p.err, p.target, !p.expect, p.expect)
+		}
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	param := &requestParameter{Method: http.MethodGet, URL: APIBaseURLForV1 + "/users"}
+
+	apiErr := newAPIError(param, http.StatusServiceUnavailable, newRejectedMock().body)
+	expect := &APIError{
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    "Please retry this request.",
+		IsRejected: true,
+		Method:     http.MethodGet,
+		URL:        APIBaseURLForV1 + "/users",
+	}
+	if *apiErr != *expect {
+		t.Errorf("got: %+v\nwant: %+v", apiErr, expect)
+	}
+
+	apiErr = newAPIError(param, http.StatusNotFound, newPageNotFoundMock().body)
+	if apiErr.Message != "404 page not found" {
+		t.Errorf("got: %s\nwant: %s", apiErr.Message, "404 page not found")
+	}
+}
+
+func TestErrorOnFailure(t *testing.T) {
+	client := New(userName, token, WithHTTPClient(newAPIFailedMock()), WithErrorOnFailure())
+	result, err := client.User().Delete()
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) == false {
+		t.Fatalf("got: %v\nwant: *APIError", err)
+	}
+	if errors.Is(err, ErrNotFound) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrNotFound)
+	}
+	if apiErr.Method != http.MethodDelete || apiErr.Message != "failed." {
+		t.Errorf("got: %+v\nwant: DELETE failed.", apiErr)
+	}
+	if result.StatusCode != http.StatusNotFound {
+		t.Errorf("got: %d\nwant: %d", result.StatusCode, http.StatusNotFound)
+	}
+
+	_, err = client.Graph().GetAll()
+	if errors.Is(err, ErrNotFound) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrNotFound)
+	}
+}
+
+func TestErrorOnFailureSuccess(t *testing.T) {
+	client := New(userName, token, WithHTTPClient(newOKMock()), WithErrorOnFailure())
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+}
+
+func TestRejectedAPIError(t *testing.T) {
+	mock := &httpClientSequenceMock{responses: []*httpClientMock{newRejectedMock()}}
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Nanosecond}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy))
+	_, err := client.User().Delete()
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) == false {
+		t.Fatalf("got: %v\nwant: *APIError", err)
+	}
+	if apiErr.IsRejected == false || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got: %+v\nwant: rejected", apiErr)
+	}
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
-
+	"slices"
+	"strings"
+	"time"
 )
 
 // A Graph manages communication with the Pixela graph API.
 type Graph struct {
-	UserName   string
-	Token      string
-	httpClient HTTPClient
+	UserName  string
+	Token     string
+	requester *requester
+	baseURL   string
 }
 
 // Create creates a new pixelation graph definition.
@@ -23,12 +27,15 @@ func (g *Graph) Create(input *GraphCreateInput) (*Result, error) {
 
 // CreateWithContext creates a new pixelation graph definition.
 func (g *Graph) CreateWithContext(ctx context.Context, input *GraphCreateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := g.createCreateRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create graph create parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, g.httpClient, param)
+	return doRequestAndParseResponse(ctx, g.requester, param)
 }
 
 // GraphCreateInput is input of Graph.Create().
@@ -51,6 +58,19 @@ type GraphCreateInput struct {
 	StartOnMonday       *bool   `json:"startOnMonday,omitempty"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphCreateInput) Validate() error {
+	v := newValidator("GraphCreateInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Name", in.Name).required()
+	v.field("Unit", in.Unit).required()
+	v.field("Type", in.Type).required().oneOf(graphTypes...)
+	v.field("Color", in.Color).required().oneOf(graphColors...)
+	v.field("TimeZone", in.TimeZone).timezone()
+	v.field("SelfSufficient", in.SelfSufficient).oneOf(selfSufficients...)
+	return v.err()
+}
+
 func (g *Graph) createCreateRequestParameter(input *GraphCreateInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -58,13 +78,17 @@ func (g *Graph) createCreateRequestParameter(input *GraphCreateInput) (*requestP
 	}
 
 	return &requestParameter{
-		Method: http.MethodPost,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs", g.UserName),
-		Header: map[string]string{userToken: g.Token},
-		Body:   b,
+		Operation: "pixela.Graph.Create",
+		Method:    http.MethodPost,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs", g.UserName),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      b,
 	}, nil
 }
 
+// dateLayout is the layout of Pixel dates (yyyyMMdd).
+const dateLayout = "20060102"
+
 // It is the type of quantity to be handled in the graph.
 // Only int or float are supported.
 const (
@@ -100,7 +124,7 @@ func (g *Graph) GetAll() (*GraphDefinitions, error) {
 
 // GetAllWithContext gets all predefined pixelation graph definitions.
 func (g *Graph) GetAllWithContext(ctx context.Context) (*GraphDefinitions, error) {
-	b, status, err := doRequest(ctx, g.httpClient, g.createGetAllRequestParameter())
+	b, status, err := doRequest(ctx, g.requester, g.createGetAllRequestParameter())
 	if err != nil {
 		return &GraphDefinitions{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -117,10 +141,11 @@ func (g *Graph) GetAllWithContext(ctx context.Context) (*GraphDefinitions, error
 
 func (g *Graph) createGetAllRequestParameter() *requestParameter {
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs", g.UserName),
-		Header: map[string]string{userToken: g.Token},
-		Body:   []byte{},
+		Operation: "pixela.Graph.GetAll",
+		Method:    http.MethodGet,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs", g.UserName),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -142,9 +167,32 @@ type GraphDefinition struct {
 	SelfSufficient      string   `json:"selfSufficient"`
 	IsSecret            bool     `json:"isSecret"`
 	PublishOptionalData bool     `json:"publishOptionalData"`
+	StartOnMonday       bool     `json:"startOnMonday"`
 	Result
 }
 
+// Location returns the timezone of the graph.
+// It returns UTC if the timezone is not specified.
+func (d *GraphDefinition) Location() (*time.Location, error) {
+	if d.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(d.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone: %w", err)
+	}
+	return loc, nil
+}
+
+// Date returns a pointer to the date of t in the timezone of the graph in yyyyMMdd format.
+func (d *GraphDefinition) Date(t time.Time) (*string, error) {
+	loc, err := d.Location()
+	if err != nil {
+		return nil, err
+	}
+	return Date(t.In(loc)), nil
+}
+
 // GetLatestPixel gets the latest Pixel registered in the graph.
 func (g *Graph) GetLatestPixel(input *GraphGetLatestPixelInput) (*GraphPixel, error) {
 	return g.GetLatestPixelWithContext(context.Background(), input)
@@ -152,7 +200,10 @@ func (g *Graph) GetLatestPixel(input *GraphGetLatestPixelInput) (*GraphPixel, er
 
 // GetLatestPixelWithContext gets the latest Pixel registered in the graph.
 func (g *Graph) GetLatestPixelWithContext(ctx context.Context, input *GraphGetLatestPixelInput) (*GraphPixel, error) {
-	b, status, err := doRequest(ctx, g.httpClient, g.createGetLatestPixelRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &GraphPixel{}, err
+	}
+	b, status, err := doRequest(ctx, g.requester, g.createGetLatestPixelRequestParameter(input))
 	if err != nil {
 		return &GraphPixel{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -170,10 +221,11 @@ func (g *Graph) GetLatestPixelWithContext(ctx context.Context, input *GraphGetLa
 func (g *Graph) createGetLatestPixelRequestParameter(input *GraphGetLatestPixelInput) *requestParameter {
 	ID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/latest", g.UserName, ID),
-		Header: map[string]string{userToken: g.Token},
-		Body:   []byte{},
+		Operation: "pixela.Graph.GetLatestPixel",
+		Method:    http.MethodGet,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/latest", g.UserName, ID),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -183,6 +235,13 @@ type GraphGetLatestPixelInput struct {
 	ID *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphGetLatestPixelInput) Validate() error {
+	v := newValidator("GraphGetLatestPixelInput")
+	v.field("ID", in.ID).required().id()
+	return v.err()
+}
+
 // GraphPixel is graph pixel
 type GraphPixel struct {
 	Date         string `json:"date"`
@@ -191,6 +250,26 @@ type GraphPixel struct {
 	Result
 }
 
+// Time returns Date as time.Time at midnight in UTC.
+func (p *GraphPixel) Time() (time.Time, error) {
+	return parseDate(p.Date, time.UTC)
+}
+
+// TimeIn returns Date as time.Time at midnight in loc.
+func (p *GraphPixel) TimeIn(loc *time.Location) (time.Time, error) {
+	return parseDate(p.Date, loc)
+}
+
+// Int returns Quantity as int64.
+func (p *GraphPixel) Int() (int64, error) {
+	return parseIntQuantity(p.Quantity)
+}
+
+// Float returns Quantity as float64.
+func (p *GraphPixel) Float() (float64, error) {
+	return parseFloatQuantity(p.Quantity)
+}
+
 // GetToday gets the Pixel registered on the day of the request.
 func (g *Graph) GetToday(input *GraphGetTodayInput) (*GraphPixel, error) {
 	return g.GetTodayWithContext(context.Background(), input)
@@ -198,7 +277,10 @@ func (g *Graph) GetToday(input *GraphGetTodayInput) (*GraphPixel, error) {
 
 // GetTodayWithContext gets the Pixel registered on the day of the request.
 func (g *Graph) GetTodayWithContext(ctx context.Context, input *GraphGetTodayInput) (*GraphPixel, error) {
-	b, status, err := doRequest(ctx, g.httpClient, g.createGetTodayRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &GraphPixel{}, err
+	}
+	b, status, err := doRequest(ctx, g.requester, g.createGetTodayRequestParameter(input))
 	if err != nil {
 		return &GraphPixel{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -217,7 +299,7 @@ func (g *Graph) createGetTodayRequestParameter(input *GraphGetTodayInput) *reque
 	ID := StringValue(input.ID)
 
 	// Create base URL without query parameters
-	baseURL := fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/today", g.UserName, ID)
+	baseURL := g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/today", g.UserName, ID)
 
 	// Create url.Values for query parameters
 	query := make(url.Values)
@@ -233,10 +315,11 @@ func (g *Graph) createGetTodayRequestParameter(input *GraphGetTodayInput) *reque
 	}
 
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    baseURL,
-		Header: map[string]string{userToken: g.Token},
-		Body:   []byte{},
+		Operation: "pixela.Graph.GetToday",
+		Method:    http.MethodGet,
+		URL:       baseURL,
+		Header:    map[string]string{userToken: g.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -250,6 +333,13 @@ type GraphGetTodayInput struct {
 	ReturnEmpty *bool `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphGetTodayInput) Validate() error {
+	v := newValidator("GraphGetTodayInput")
+	v.field("ID", in.ID).required().id()
+	return v.err()
+}
+
 // GetSVG get a graph expressed in SVG format diagram that based on the registered information.
 func (g *Graph) GetSVG(input *GraphGetSVGInput) (string, error) {
 	return g.GetSVGWithContext(context.Background(), input)
@@ -257,7 +347,10 @@ func (g *Graph) GetSVG(input *GraphGetSVGInput) (string, error) {
 
 // GetSVGWithContext get a graph expressed in SVG format diagram that based on the registered information.
 func (g *Graph) GetSVGWithContext(ctx context.Context, input *GraphGetSVGInput) (string, error) {
-	b, err := mustDoRequest(ctx, g.httpClient, g.createGetSVGRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+	b, err := mustDoRequest(ctx, g.requester, g.createGetSVGRequestParameter(input))
 	if err != nil {
 		return "", fmt.Errorf("failed to do request: %w", err)
 	}
@@ -276,11 +369,23 @@ type GraphGetSVGInput struct {
 	GreaterThan *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphGetSVGInput) Validate() error {
+	v := newValidator("GraphGetSVGInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Date", in.Date).date()
+	v.field("Mode", in.Mode).oneOf(GraphModeShort, GraphModeBadge, GraphModeLine)
+	v.field("Appearance", in.Appearance).oneOf(GraphAppearanceDark)
+	v.field("LessThan", in.LessThan).quantity("")
+	v.field("GreaterThan", in.GreaterThan).quantity("")
+	return v.err()
+}
+
 func (g *Graph) createGetSVGRequestParameter(input *GraphGetSVGInput) *requestParameter {
 	ID := StringValue(input.ID)
 
 	// Create base URL without query parameters
-	baseURL := fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s", g.UserName, ID)
+	baseURL := g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s", g.UserName, ID)
 
 	// Create url.Values for query parameters
 	query := make(url.Values)
@@ -317,10 +422,11 @@ func (g *Graph) createGetSVGRequestParameter(input *GraphGetSVGInput) *requestPa
 	}
 
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    baseURL,
-		Header: map[string]string{userToken: g.Token},
-		Body:   []byte{},
+		Operation: "pixela.Graph.GetSVG",
+		Method:    http.MethodGet,
+		URL:       baseURL,
+		Header:    map[string]string{userToken: g.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -351,12 +457,15 @@ func (g *Graph) UpdatePixels(input *GraphUpdatePixelsInput) (*Result, error) {
 
 // UpdatePixelsWithContext is used to register multiple Pixels (quantities for a specific day) at a time.
 func (g *Graph) UpdatePixelsWithContext(ctx context.Context, input *GraphUpdatePixelsInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := g.createUpdatePixelsRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create graph update pixels parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, g.httpClient, param)
+	return doRequestAndParseResponse(ctx, g.requester, param)
 }
 
 // GraphUpdatePixelsInput is input of Graph.UpdatePixels().
@@ -366,6 +475,19 @@ type GraphUpdatePixelsInput struct {
 	Pixels []PixelInput `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphUpdatePixelsInput) Validate() error {
+	v := newValidator("GraphUpdatePixelsInput")
+	v.field("ID", in.ID).required().id()
+	if len(in.Pixels) > MaxUpdatePixels {
+		v.add("Pixels", "must not have more than %d pixels", MaxUpdatePixels)
+	}
+	for i := range in.Pixels {
+		in.Pixels[i].validate(v, fmt.Sprintf("Pixels[%d].", i), "")
+	}
+	return v.err()
+}
+
 // PixelInput is input of Graph.UpdatePixels().
 type PixelInput struct {
 	// Date is a required field
@@ -375,6 +497,20 @@ type PixelInput struct {
 	OptionalData *string `json:"optionalData,omitempty"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelInput) Validate() error {
+	v := newValidator("PixelInput")
+	in.validate(v, "", "")
+	return v.err()
+}
+
+// validate checks the fields of the pixel, whose names are prefixed with prefix.
+// The quantity is checked against graphType unless it is "".
+func (in *PixelInput) validate(v *validator, prefix, graphType string) {
+	v.field(prefix+"Date", in.Date).required().date()
+	v.field(prefix+"Quantity", in.Quantity).required().quantity(graphType)
+}
+
 func (g *Graph) createUpdatePixelsRequestParameter(input *GraphUpdatePixelsInput) (*requestParameter, error) {
 	b, err := json.Marshal(input.Pixels)
 	if err != nil {
@@ -383,10 +519,11 @@ func (g *Graph) createUpdatePixelsRequestParameter(input *GraphUpdatePixelsInput
 
 	ID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodPost,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/pixels", g.UserName, ID),
-		Header: map[string]string{userToken: g.Token},
-		Body:   b,
+		Operation: "pixela.Graph.UpdatePixels",
+		Method:    http.MethodPost,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/pixels", g.UserName, ID),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -395,10 +532,10 @@ func (g *Graph) URL(input *GraphURLInput) string {
 	ID := StringValue(input.ID)
 	mode := StringValue(input.Mode)
 	if mode == "" {
-		return fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s.html", g.UserName, ID)
+		return g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s.html", g.UserName, ID)
 	}
 
-	return fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s.html?mode=%s", g.UserName, ID, mode)
+	return g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s.html?mode=%s", g.UserName, ID, mode)
 }
 
 // GraphURLInput is input of Graph.GetURL().
@@ -408,39 +545,111 @@ type GraphURLInput struct {
 	Mode *string
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphURLInput) Validate() error {
+	v := newValidator("GraphURLInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Mode", in.Mode).oneOf(GraphModeShort, GraphModeSimple, GraphModeSimpleShort)
+	return v.err()
+}
+
 // Stats is various statistics based on the registered information.
+// The quantities are decoded as they are in the response, so that the quantities of float graphs are not truncated.
 type Stats struct {
-	TotalPixelsCount  int     `json:"totalPixelsCount"`
-	MaxQuantity       int     `json:"maxQuantity"`
-	MaxDate           string  `json:"maxDate"`
-	MinQuantity       int     `json:"minQuantity"`
-	MinDate           string  `json:"minDate"`
-	TotalQuantity     int     `json:"totalQuantity"`
-	AvgQuantity       float64 `json:"avgQuantity"`
-	TodaysQuantity    int     `json:"todaysQuantity"`
-	YesterdayQuantity int     `json:"yesterdayQuantity"`
+	TotalPixelsCount  int           `json:"totalPixelsCount"`
+	MaxQuantity       StatsQuantity `json:"maxQuantity"`
+	MaxDate           string        `json:"maxDate"`
+	MinQuantity       StatsQuantity `json:"minQuantity"`
+	MinDate           string        `json:"minDate"`
+	TotalQuantity     StatsQuantity `json:"totalQuantity"`
+	AvgQuantity       float64       `json:"avgQuantity"`
+	TodaysQuantity    StatsQuantity `json:"todaysQuantity"`
+	YesterdayQuantity StatsQuantity `json:"yesterdayQuantity"`
+	// GraphType is the type of the graph (GraphTypeInt or GraphTypeFloat) that the quantities belong to.
+	// It is set only if GraphStatsInput.WithGraphType is true.
+	GraphType string `json:"graphType,omitempty"`
 	Result
 }
 
+// StatsQuantity is a quantity of Stats decoded losslessly from a JSON number.
+// It is empty if the field is missing in the response.
+type StatsQuantity string
+
+// String returns the quantity as it is in the response.
+func (q StatsQuantity) String() string {
+	return string(q)
+}
+
+// Int returns the quantity as int64. It returns 0 if the quantity is empty.
+func (q StatsQuantity) Int() (int64, error) {
+	if q == "" {
+		return 0, nil
+	}
+	return parseIntQuantity(string(q))
+}
+
+// Float returns the quantity as float64. It returns 0 if the quantity is empty.
+func (q StatsQuantity) Float() (float64, error) {
+	if q == "" {
+		return 0, nil
+	}
+	return parseFloatQuantity(string(q))
+}
+
+// UnmarshalJSON decodes a JSON number, a string of a number or null without losing precision.
+func (q *StatsQuantity) UnmarshalJSON(b []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("failed to unmarshal quantity: %w", err)
+	}
+	*q = StatsQuantity(n)
+	return nil
+}
+
+// MarshalJSON encodes the quantity as a JSON number, or null if it is empty.
+func (q StatsQuantity) MarshalJSON() ([]byte, error) {
+	if q == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(json.Number(q))
+}
+
 // Stats gets various statistics based on the registered information.
 func (g *Graph) Stats(input *GraphStatsInput) (*Stats, error) {
 	return g.StatsWithContext(context.Background(), input)
 }
 
 // StatsWithContext gets various statistics based on the registered information.
+// If input.WithGraphType is true, it also gets the graph definition to set Stats.GraphType.
 func (g *Graph) StatsWithContext(ctx context.Context, input *GraphStatsInput) (*Stats, error) {
-	b, status, err := doRequest(ctx, g.httpClient, g.createStatsRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Stats{}, err
+	}
+	b, status, err := doRequest(ctx, g.requester, g.createStatsRequestParameter(input))
 	if err != nil {
-		return nil, fmt.Errorf("failed to do request: %w", err)
+		return &Stats{}, fmt.Errorf("failed to do request: %w", err)
 	}
 
 	var stats Stats
 	stats.StatusCode = status
 	if err := json.Unmarshal(b, &stats); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal json: %w", err)
+		return &Stats{}, fmt.Errorf("failed to unmarshal json: %w", err)
 	}
 
 	stats.IsSuccess = stats.Message == ""
+	if !stats.IsSuccess || !input.WithGraphType {
+		return &stats, nil
+	}
+
+	getInput := &GraphGetInput{ID: input.ID}
+	def, err := g.GetWithContext(ctx, getInput)
+	if err != nil {
+		return &Stats{}, fmt.Errorf("failed to get graph type: %w", err)
+	}
+	if !def.IsSuccess {
+		return &Stats{}, fmt.Errorf("failed to get graph type: %w", newResultError(g.createGetRequestParameter(getInput), &def.Result))
+	}
+	stats.GraphType = def.Type
 	return &stats, nil
 }
 
@@ -448,15 +657,25 @@ func (g *Graph) StatsWithContext(ctx context.Context, input *GraphStatsInput) (*
 type GraphStatsInput struct {
 	// ID is a required field
 	ID *string `json:"-"`
+	// WithGraphType makes Stats get the graph definition with another request to set Stats.GraphType.
+	WithGraphType bool `json:"-"`
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphStatsInput) Validate() error {
+	v := newValidator("GraphStatsInput")
+	v.field("ID", in.ID).required().id()
+	return v.err()
 }
 
 func (g *Graph) createStatsRequestParameter(input *GraphStatsInput) *requestParameter {
 	ID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/stats", g.UserName, ID),
-		Header: map[string]string{},
-		Body:   []byte{},
+		Operation: "pixela.Graph.Stats",
+		Method:    http.MethodGet,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/stats", g.UserName, ID),
+		Header:    map[string]string{},
+		Body:      []byte{},
 	}
 }
 
@@ -469,12 +688,15 @@ func (g *Graph) Update(input *GraphUpdateInput) (*Result, error) {
 // UpdateWithContext updates predefined pixelation graph definitions.
 // The items that can be updated are limited as compared with the pixelation graph definition creation.
 func (g *Graph) UpdateWithContext(ctx context.Context, input *GraphUpdateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := g.createUpdateRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create graph update parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, g.httpClient, param)
+	return doRequestAndParseResponse(ctx, g.requester, param)
 }
 
 // GraphUpdateInput is input of Graph.Update().
@@ -493,6 +715,16 @@ type GraphUpdateInput struct {
 	StartOnMonday       *bool    `json:"startOnMonday,omitempty"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphUpdateInput) Validate() error {
+	v := newValidator("GraphUpdateInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Color", in.Color).oneOf(graphColors...)
+	v.field("TimeZone", in.TimeZone).timezone()
+	v.field("SelfSufficient", in.SelfSufficient).oneOf(selfSufficients...)
+	return v.err()
+}
+
 func (g *Graph) createUpdateRequestParameter(input *GraphUpdateInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -501,10 +733,11 @@ func (g *Graph) createUpdateRequestParameter(input *GraphUpdateInput) (*requestP
 
 	ID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s", g.UserName, ID),
-		Header: map[string]string{userToken: g.Token},
-		Body:   b,
+		Operation: "pixela.Graph.Update",
+		Method:    http.MethodPut,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s", g.UserName, ID),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -515,7 +748,10 @@ func (g *Graph) Delete(input *GraphDeleteInput) (*Result, error) {
 
 // DeleteWithContext deletes the predefined pixelation graph definition.
 func (g *Graph) DeleteWithContext(ctx context.Context, input *GraphDeleteInput) (*Result, error) {
-	return doRequestAndParseResponse(ctx, g.httpClient, g.createDeleteRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, g.requester, g.createDeleteRequestParameter(input))
 }
 
 // GraphDeleteInput is input of Graph.Delete().
@@ -524,13 +760,21 @@ type GraphDeleteInput struct {
 	ID *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphDeleteInput) Validate() error {
+	v := newValidator("GraphDeleteInput")
+	v.field("ID", in.ID).required().id()
+	return v.err()
+}
+
 func (g *Graph) createDeleteRequestParameter(input *GraphDeleteInput) *requestParameter {
 	ID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodDelete,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s", g.UserName, ID),
-		Header: map[string]string{userToken: g.Token},
-		Body:   []byte{},
+		Operation: "pixela.Graph.Delete",
+		Method:    http.MethodDelete,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s", g.UserName, ID),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -549,6 +793,9 @@ func (g *Graph) createDeleteRequestParameter(input *GraphDeleteInput) *requestPa
 // If you specify both from andto;
 // You will get a list you specify.
 // You can not specify a period greater than 365 days.
+//
+// Deprecated: Pixels.Pixels is []string or []PixelWithBody depending on WithBody.
+// Use GetPixelDateList or GetPixelsWithBody instead.
 func (g *Graph) GetPixelDates(input *GraphGetPixelDatesInput) (*Pixels, error) {
 	return g.GetPixelDatesWithContext(context.Background(), input)
 }
@@ -568,29 +815,42 @@ func (g *Graph) GetPixelDates(input *GraphGetPixelDatesInput) (*Pixels, error) {
 // If you specify both from andto;
 // You will get a list you specify.
 // You can not specify a period greater than 365 days.
+//
+// Deprecated: Pixels.Pixels is []string or []PixelWithBody depending on WithBody.
+// Use GetPixelDateListWithContext or GetPixelsWithBodyWithContext instead.
 func (g *Graph) GetPixelDatesWithContext(ctx context.Context, input *GraphGetPixelDatesInput) (*Pixels, error) {
-	b, status, err := doRequest(ctx, g.httpClient, g.createGetPixelDatesRequestParameter(input))
-	if err != nil {
-		return &Pixels{}, fmt.Errorf("failed to do request: %w", err)
+	if BoolValue(input.WithBody) {
+		pixels, err := g.GetPixelsWithBodyWithContext(ctx, input)
+		if err != nil {
+			return &Pixels{}, err
+		}
+		return &Pixels{Pixels: pixels.Pixels, Result: pixels.Result}, nil
 	}
 
-	pixels, err := unmarshalPixels(b, BoolValue(input.WithBody))
+	list, err := g.GetPixelDateListWithContext(ctx, input)
 	if err != nil {
-		return &Pixels{}, fmt.Errorf("failed to unmarshal json: %w", err)
+		return &Pixels{}, err
 	}
-
-	pixels.StatusCode = status
-	pixels.IsSuccess = pixels.Message == ""
-	return pixels, nil
+	return &Pixels{Pixels: list.Dates, Result: list.Result}, nil
 }
 
 // GraphGetPixelDatesInput is input of Graph.GetPixelDates().
 type GraphGetPixelDatesInput struct {
 	// ID is a required field
-	ID       *string `json:"-"`
-	From     *string `json:"-"`
-	To       *string `json:"-"`
-	WithBody *bool   `json:"-"`
+	ID   *string `json:"-"`
+	From *string `json:"-"`
+	To   *string `json:"-"`
+	// WithBody is ignored by GetPixelDateList and GetPixelsWithBody.
+	WithBody *bool `json:"-"`
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphGetPixelDatesInput) Validate() error {
+	v := newValidator("GraphGetPixelDatesInput")
+	v.field("ID", in.ID).required().id()
+	v.field("From", in.From).date()
+	v.field("To", in.To).date()
+	return v.err()
 }
 
 // Pixels is Date list of Pixel registered in the graph.
@@ -601,6 +861,20 @@ type Pixels struct {
 	Result
 }
 
+// Dates returns Pixels as []string.
+// It returns nil when Pixels is not []string.
+func (p *Pixels) Dates() []string {
+	dates, _ := p.Pixels.([]string)
+	return dates
+}
+
+// WithBody returns Pixels as []PixelWithBody.
+// It returns nil when Pixels is not []PixelWithBody.
+func (p *Pixels) WithBody() []PixelWithBody {
+	pixels, _ := p.Pixels.([]PixelWithBody)
+	return pixels
+}
+
 // PixelWithBody is Date of Pixel registered in the graph.
 type PixelWithBody struct {
 	Date         string `json:"date"`
@@ -608,46 +882,148 @@ type PixelWithBody struct {
 	OptionalData string `json:"optionalData"`
 }
 
-func unmarshalPixels(b []byte, withBody bool) (*Pixels, error) {
-	if withBody {
-		return unmarshalPixelsWithBody(b)
-	}
-	return unmarshalPixelsNoBody(b)
+// Time returns Date as time.Time at midnight in UTC.
+func (p PixelWithBody) Time() (time.Time, error) {
+	return parseDate(p.Date, time.UTC)
+}
+
+// TimeIn returns Date as time.Time at midnight in loc.
+func (p PixelWithBody) TimeIn(loc *time.Location) (time.Time, error) {
+	return parseDate(p.Date, loc)
+}
+
+// Int returns Quantity as int64.
+func (p PixelWithBody) Int() (int64, error) {
+	return parseIntQuantity(p.Quantity)
+}
+
+// Float returns Quantity as float64.
+func (p PixelWithBody) Float() (float64, error) {
+	return parseFloatQuantity(p.Quantity)
+}
+
+func (p PixelWithBody) input() PixelInput {
+	return PixelInput{Date: String(p.Date), Quantity: String(p.Quantity), OptionalData: nonEmpty(p.OptionalData)}
+}
+
+// GetPixelDateList gets a Date list of Pixel registered in the graph specified by graphID.
+// You can specify a period with from and to parameters.
+//
+// If you do not specify both from and to;
+// You will get a list of 365 days ago from today.
+//
+// If you specify from only;
+// You will get a list of 365 days from from date.
+//
+// If you specify to only;
+// You will get a list of 365 days ago from to date.
+//
+// If you specify both from andto;
+// You will get a list you specify.
+// You can not specify a period greater than 365 days.
+func (g *Graph) GetPixelDateList(input *GraphGetPixelDatesInput) (*PixelDateList, error) {
+	return g.GetPixelDateListWithContext(context.Background(), input)
 }
 
-func unmarshalPixelsWithBody(b []byte) (*Pixels, error) {
-	var pixels struct {
-		Pixels []PixelWithBody `json:"pixels"`
-		Result
+// GetPixelDateListWithContext gets a Date list of Pixel registered in the graph specified by graphID.
+// See GetPixelDateList for the period.
+func (g *Graph) GetPixelDateListWithContext(ctx context.Context, input *GraphGetPixelDatesInput) (*PixelDateList, error) {
+	if err := input.Validate(); err != nil {
+		return &PixelDateList{}, err
 	}
-	if err := json.Unmarshal(b, &pixels); err != nil {
-		return &Pixels{}, fmt.Errorf("failed to unmarshal json: %w", err)
+	in := *input
+	in.WithBody = nil
+	b, status, err := doRequest(ctx, g.requester, g.createGetPixelDatesRequestParameter(&in))
+	if err != nil {
+		return &PixelDateList{}, fmt.Errorf("failed to do request: %w", err)
 	}
 
-	return &Pixels{
-		Pixels: pixels.Pixels,
-		Result: pixels.Result,
-	}, nil
+	var list PixelDateList
+	if err := json.Unmarshal(b, &list); err != nil {
+		return &PixelDateList{}, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+
+	list.StatusCode = status
+	list.IsSuccess = list.Message == ""
+	return &list, nil
+}
+
+// PixelDateList is Date list of Pixel registered in the graph.
+type PixelDateList struct {
+	// Dates is in yyyyMMdd format.
+	Dates []string `json:"pixels"`
+	Result
+}
+
+// Times returns Dates as time.Time at midnight in UTC.
+func (l *PixelDateList) Times() ([]time.Time, error) {
+	return l.TimesIn(time.UTC)
+}
+
+// TimesIn returns Dates as time.Time at midnight in loc.
+func (l *PixelDateList) TimesIn(loc *time.Location) ([]time.Time, error) {
+	times := make([]time.Time, 0, len(l.Dates))
+	for _, d := range l.Dates {
+		t, err := parseDate(d, loc)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// GetPixelsWithBody gets a list of Pixel with its quantity and optional data registered in the graph specified by graphID.
+// You can specify a period with from and to parameters.
+//
+// If you do not specify both from and to;
+// You will get a list of 365 days ago from today.
+//
+// If you specify from only;
+// You will get a list of 365 days from from date.
+//
+// If you specify to only;
+// You will get a list of 365 days ago from to date.
+//
+// If you specify both from andto;
+// You will get a list you specify.
+// You can not specify a period greater than 365 days.
+func (g *Graph) GetPixelsWithBody(input *GraphGetPixelDatesInput) (*PixelsWithBody, error) {
+	return g.GetPixelsWithBodyWithContext(context.Background(), input)
 }
 
-func unmarshalPixelsNoBody(b []byte) (*Pixels, error) {
-	var pixels struct {
-		Pixels []string `json:"pixels"`
-		Result
+// GetPixelsWithBodyWithContext gets a list of Pixel with its quantity and optional data registered in the graph specified by graphID.
+// See GetPixelsWithBody for the period.
+func (g *Graph) GetPixelsWithBodyWithContext(ctx context.Context, input *GraphGetPixelDatesInput) (*PixelsWithBody, error) {
+	if err := input.Validate(); err != nil {
+		return &PixelsWithBody{}, err
+	}
+	in := *input
+	in.WithBody = Bool(true)
+	b, status, err := doRequest(ctx, g.requester, g.createGetPixelDatesRequestParameter(&in))
+	if err != nil {
+		return &PixelsWithBody{}, fmt.Errorf("failed to do request: %w", err)
 	}
+
+	var pixels PixelsWithBody
 	if err := json.Unmarshal(b, &pixels); err != nil {
-		return &Pixels{}, fmt.Errorf("failed to unmarshal json: %w", err)
+		return &PixelsWithBody{}, fmt.Errorf("failed to unmarshal json: %w", err)
 	}
 
-	return &Pixels{
-		Pixels: pixels.Pixels,
-		Result: pixels.Result,
-	}, nil
+	pixels.StatusCode = status
+	pixels.IsSuccess = pixels.Message == ""
+	return &pixels, nil
+}
+
+// PixelsWithBody is a list of Pixel registered in the graph.
+type PixelsWithBody struct {
+	Pixels []PixelWithBody `json:"pixels"`
+	Result
 }
 
 func (g *Graph) createGetPixelDatesRequestParameter(input *GraphGetPixelDatesInput) *requestParameter {
 	ID := StringValue(input.ID)
-	baseURL := fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/pixels", g.UserName, ID)
+	baseURL := g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/pixels", g.UserName, ID)
 
 	query := make(url.Values)
 	if from := StringValue(input.From); from != "" {
@@ -656,18 +1032,21 @@ func (g *Graph) createGetPixelDatesRequestParameter(input *GraphGetPixelDatesInp
 	if to := StringValue(input.To); to != "" {
 		query.Set("to", to)
 	}
+	operation := "pixela.Graph.GetPixelDateList"
 	if BoolValue(input.WithBody) {
 		query.Set("withBody", "true")
+		operation = "pixela.Graph.GetPixelsWithBody"
 	}
 	if len(query) > 0 {
 		baseURL = baseURL + "?" + query.Encode()
 	}
 
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    baseURL,
-		Header: map[string]string{userToken: g.Token},
-		Body:   []byte{},
+		Operation: operation,
+		Method:    http.MethodGet,
+		URL:       baseURL,
+		Header:    map[string]string{userToken: g.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -678,7 +1057,10 @@ func (g *Graph) Stopwatch(input *GraphStopwatchInput) (*Result, error) {
 
 // StopwatchWithContext start and end the measurement of the time.
 func (g *Graph) StopwatchWithContext(ctx context.Context, input *GraphStopwatchInput) (*Result, error) {
-	return doRequestAndParseResponse(ctx, g.httpClient, g.createStopwatchRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, g.requester, g.createStopwatchRequestParameter(input))
 }
 
 // GraphStopwatchInput is input of Graph.Stopwatch().
@@ -687,13 +1069,21 @@ type GraphStopwatchInput struct {
 	ID *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphStopwatchInput) Validate() error {
+	v := newValidator("GraphStopwatchInput")
+	v.field("ID", in.ID).required().id()
+	return v.err()
+}
+
 func (g *Graph) createStopwatchRequestParameter(input *GraphStopwatchInput) *requestParameter {
 	graphID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodPost,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/stopwatch", g.UserName, graphID),
-		Header: map[string]string{contentLength: "0", userToken: g.Token},
-		Body:   []byte{},
+		Operation: "pixela.Graph.Stopwatch",
+		Method:    http.MethodPost,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/stopwatch", g.UserName, graphID),
+		Header:    map[string]string{contentLength: "0", userToken: g.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -704,7 +1094,10 @@ func (g *Graph) Get(input *GraphGetInput) (*GraphDefinition, error) {
 
 // GetWithContext gets predefined pixelation graph definitions.
 func (g *Graph) GetWithContext(ctx context.Context, input *GraphGetInput) (*GraphDefinition, error) {
-	b, status, err := doRequest(ctx, g.httpClient, g.createGetRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &GraphDefinition{}, err
+	}
+	b, status, err := doRequest(ctx, g.requester, g.createGetRequestParameter(input))
 	if err != nil {
 		return &GraphDefinition{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -722,10 +1115,11 @@ func (g *Graph) GetWithContext(ctx context.Context, input *GraphGetInput) (*Grap
 func (g *Graph) createGetRequestParameter(input *GraphGetInput) *requestParameter {
 	ID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/graph-def", g.UserName, ID),
-		Header: map[string]string{userToken: g.Token},
-		Body:   []byte{},
+		Operation: "pixela.Graph.Get",
+		Method:    http.MethodGet,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/graph-def", g.UserName, ID),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -735,6 +1129,13 @@ type GraphGetInput struct {
 	ID *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphGetInput) Validate() error {
+	v := newValidator("GraphGetInput")
+	v.field("ID", in.ID).required().id()
+	return v.err()
+}
+
 // Add quantity to the "Pixel" of the day.
 func (g *Graph) Add(input *GraphAddInput) (*Result, error) {
 	return g.AddWithContext(context.Background(), input)
@@ -742,12 +1143,15 @@ func (g *Graph) Add(input *GraphAddInput) (*Result, error) {
 
 // AddWithContext quantity to the "Pixel" of the day.
 func (g *Graph) AddWithContext(ctx context.Context, input *GraphAddInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := g.createAddRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create graph add parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, g.httpClient, param)
+	return doRequestAndParseResponse(ctx, g.requester, param)
 }
 
 // GraphAddInput is input of Graph.Add().
@@ -758,6 +1162,14 @@ type GraphAddInput struct {
 	Quantity *string `json:"quantity"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphAddInput) Validate() error {
+	v := newValidator("GraphAddInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Quantity", in.Quantity).required().quantity("")
+	return v.err()
+}
+
 func (g *Graph) createAddRequestParameter(input *GraphAddInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -766,10 +1178,11 @@ func (g *Graph) createAddRequestParameter(input *GraphAddInput) (*requestParamet
 
 	graphID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/add", g.UserName, graphID),
-		Header: map[string]string{userToken: g.Token},
-		Body:   b,
+		Operation: "pixela.Graph.Add",
+		Method:    http.MethodPut,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/add", g.UserName, graphID),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -780,12 +1193,15 @@ func (g *Graph) Subtract(input *GraphSubtractInput) (*Result, error) {
 
 // SubtractWithContext quantity from the "Pixel" of the day.
 func (g *Graph) SubtractWithContext(ctx context.Context, input *GraphSubtractInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := g.createSubtractRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create graph subtract parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, g.httpClient, param)
+	return doRequestAndParseResponse(ctx, g.requester, param)
 }
 
 // GraphSubtractInput is input of Graph.Subtract().
@@ -796,6 +1212,14 @@ type GraphSubtractInput struct {
 	Quantity *string `json:"quantity"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphSubtractInput) Validate() error {
+	v := newValidator("GraphSubtractInput")
+	v.field("ID", in.ID).required().id()
+	v.field("Quantity", in.Quantity).required().quantity("")
+	return v.err()
+}
+
 func (g *Graph) createSubtractRequestParameter(input *GraphSubtractInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -804,10 +1228,11 @@ func (g *Graph) createSubtractRequestParameter(input *GraphSubtractInput) (*requ
 
 	graphID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/subtract", g.UserName, graphID),
-		Header: map[string]string{userToken: g.Token},
-		Body:   b,
+		Operation: "pixela.Graph.Subtract",
+		Method:    http.MethodPut,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/subtract", g.UserName, graphID),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -818,7 +1243,10 @@ func (g *Graph) Analyze(input *GraphAnalyzeInput) (*GraphAnalysis, error) {
 
 // AnalyzeWithContext analyzes the graph by AI and returns the result.
 func (g *Graph) AnalyzeWithContext(ctx context.Context, input *GraphAnalyzeInput) (*GraphAnalysis, error) {
-	b, status, err := doRequest(ctx, g.httpClient, g.createAnalyzeRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &GraphAnalysis{}, err
+	}
+	b, status, err := doRequest(ctx, g.requester, g.createAnalyzeRequestParameter(input))
 	if err != nil {
 		return &GraphAnalysis{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -839,6 +1267,13 @@ type GraphAnalyzeInput struct {
 	ID *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphAnalyzeInput) Validate() error {
+	v := newValidator("GraphAnalyzeInput")
+	v.field("ID", in.ID).required().id()
+	return v.err()
+}
+
 // GraphAnalysis is the response of Graph.Analyze().
 type GraphAnalysis struct {
 	Analysis string `json:"analysis"`
@@ -848,9 +1283,238 @@ type GraphAnalysis struct {
 func (g *Graph) createAnalyzeRequestParameter(input *GraphAnalyzeInput) *requestParameter {
 	ID := StringValue(input.ID)
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/analyze", g.UserName, ID),
-		Header: map[string]string{userToken: g.Token},
-		Body:   []byte{},
+		Operation: "pixela.Graph.Analyze",
+		Method:    http.MethodGet,
+		URL:       g.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/analyze", g.UserName, ID),
+		Header:    map[string]string{userToken: g.Token},
+		Body:      []byte{},
+	}
+}
+
+// maxPixelDatesDays is the number of days that GetPixelDates can get at a time.
+const maxPixelDatesDays = 365
+
+// AllPixels returns an iterator over Pixels registered in the graph from From to To in date order.
+// Unlike GetPixelsWithBody, the period can be greater than 365 days;
+// it is split into 365-day windows that are fetched up to Concurrency at a time.
+// The iteration stops at the first error, which is yielded with a zero PixelWithBody.
+func (g *Graph) AllPixels(ctx context.Context, input *GraphAllPixelsInput) iter.Seq2[PixelWithBody, error] {
+	return func(yield func(PixelWithBody, error) bool) {
+		if err := input.Validate(); err != nil {
+			yield(PixelWithBody{}, err)
+			return
+		}
+
+		windows, err := pixelDatesWindows(StringValue(input.From), StringValue(input.To))
+		if err != nil {
+			yield(PixelWithBody{}, err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		concurrency := max(input.Concurrency, 1)
+		sem := make(chan struct{}, concurrency)
+		results := make([]chan pixelsWindowResult, len(windows))
+		for i := range results {
+			results[i] = make(chan pixelsWindowResult, 1)
+		}
+		go func() {
+			for i, w := range windows {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				go func() {
+					results[i] <- g.getPixelsWindow(ctx, StringValue(input.ID), w)
+				}()
+			}
+		}()
+
+		last := ""
+		for i := range windows {
+			var r pixelsWindowResult
+			select {
+			case r = <-results[i]:
+			case <-ctx.Done():
+				yield(PixelWithBody{}, ctx.Err())
+				return
+			}
+			<-sem
+
+			if r.err != nil {
+				yield(PixelWithBody{}, r.err)
+				return
+			}
+			for _, p := range r.pixels {
+				if p.Date <= last {
+					continue
+				}
+				last = p.Date
+				if !yield(p, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GraphAllPixelsInput is input of Graph.AllPixels().
+type GraphAllPixelsInput struct {
+	// ID is a required field
+	ID *string `json:"-"`
+	// From is a required field
+	From *string `json:"-"`
+	// To is a required field
+	To *string `json:"-"`
+	// Concurrency is the number of windows fetched at a time (default: 1).
+	Concurrency int `json:"-"`
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphAllPixelsInput) Validate() error {
+	v := newValidator("GraphAllPixelsInput")
+	v.field("ID", in.ID).required().id()
+	v.field("From", in.From).required().date()
+	v.field("To", in.To).required().date()
+	if in.Concurrency < 0 {
+		v.add("Concurrency", "must not be negative")
 	}
+	return v.err()
+}
+
+type pixelsWindow struct {
+	from string
+	to   string
+}
+
+type pixelsWindowResult struct {
+	pixels []PixelWithBody
+	err    error
+}
+
+// pixelDatesWindows splits the period from from to to (yyyyMMdd, inclusive) into windows of 365 days.
+func pixelDatesWindows(from, to string) ([]pixelsWindow, error) {
+	start, err := parseDate(from, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from: %w", err)
+	}
+	end, err := parseDate(to, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to: %w", err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("to %s is before from %s", to, from)
+	}
+
+	var windows []pixelsWindow
+	for s := start; !s.After(end); s = s.AddDate(0, 0, maxPixelDatesDays) {
+		e := s.AddDate(0, 0, maxPixelDatesDays-1)
+		if e.After(end) {
+			e = end
+		}
+		windows = append(windows, pixelsWindow{from: s.Format(dateLayout), to: e.Format(dateLayout)})
+	}
+	return windows, nil
+}
+
+func (g *Graph) getPixelsWindow(ctx context.Context, id string, w pixelsWindow) pixelsWindowResult {
+	input := &GraphGetPixelDatesInput{ID: String(id), From: String(w.from), To: String(w.to)}
+	pixels, err := g.GetPixelsWithBodyWithContext(ctx, input)
+	if err != nil {
+		return pixelsWindowResult{err: fmt.Errorf("failed to get pixels from %s to %s: %w", w.from, w.to, err)}
+	}
+	if !pixels.IsSuccess {
+		param := g.createGetPixelDatesRequestParameter(&GraphGetPixelDatesInput{ID: input.ID, From: input.From, To: input.To, WithBody: Bool(true)})
+		apiErr := newResultError(param, &pixels.Result)
+		return pixelsWindowResult{err: fmt.Errorf("failed to get pixels from %s to %s: %w", w.from, w.to, apiErr)}
+	}
+
+	slices.SortFunc(pixels.Pixels, func(a, b PixelWithBody) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+	return pixelsWindowResult{pixels: pixels.Pixels}
+}
+
+// historyFloor is the earliest date searched for the pixels of the whole history of a graph.
+const historyFloor = "19000101"
+
+// pixelsOf returns an iterator over the pixels of the graph of def from from to to in date order.
+// If from or to is nil, the period is not bounded on that side: the whole history of the graph is searched
+// so that no pixel is dropped, such as the pixels before 2000 or after today.
+func (g *Graph) pixelsOf(ctx context.Context, def *GraphDefinition, from, to *string, concurrency int) iter.Seq2[PixelWithBody, error] {
+	if from != nil && to != nil {
+		return g.AllPixels(ctx, &GraphAllPixelsInput{ID: &def.ID, From: from, To: to, Concurrency: concurrency})
+	}
+	return func(yield func(PixelWithBody, error) bool) {
+		pixels, err := g.history(ctx, def)
+		if err != nil {
+			yield(PixelWithBody{}, err)
+			return
+		}
+		for _, p := range pixels {
+			if (from != nil && p.Date < *from) || (to != nil && p.Date > *to) {
+				continue
+			}
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
+}
+
+// history returns all the pixels of the graph of def in date order.
+// It gets 365-day windows backward from the later of today in the timezone of the graph and the latest pixel
+// until it finds Stats.TotalPixelsCount pixels, and returns an error if it does not find them all.
+func (g *Graph) history(ctx context.Context, def *GraphDefinition) ([]PixelWithBody, error) {
+	statsInput := &GraphStatsInput{ID: &def.ID}
+	stats, err := g.StatsWithContext(ctx, statsInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats of graph %s: %w", def.ID, err)
+	}
+	if !stats.IsSuccess {
+		return nil, fmt.Errorf("failed to get stats of graph %s: %w", def.ID, newResultError(g.createStatsRequestParameter(statsInput), &stats.Result))
+	}
+	total := stats.TotalPixelsCount
+	if total == 0 {
+		return nil, nil
+	}
+
+	today, err := def.Date(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	last := *today
+	latest, err := g.GetLatestPixelWithContext(ctx, &GraphGetLatestPixelInput{ID: &def.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest pixel of graph %s: %w", def.ID, err)
+	}
+	if latest.IsSuccess && latest.Date > last {
+		last = latest.Date
+	}
+
+	end, err := parseDate(last, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date: %w", err)
+	}
+	floor, _ := parseDate(historyFloor, time.UTC)
+	var pixels []PixelWithBody
+	for len(pixels) < total && !end.Before(floor) {
+		start := end.AddDate(0, 0, -(maxPixelDatesDays - 1))
+		if start.Before(floor) {
+			start = floor
+		}
+		r := g.getPixelsWindow(ctx, def.ID, pixelsWindow{from: start.Format(dateLayout), to: end.Format(dateLayout)})
+		if r.err != nil {
+			return nil, r.err
+		}
+		pixels = append(r.pixels, pixels...)
+		end = start.AddDate(0, 0, -1)
+	}
+	if len(pixels) < total {
+		return nil, fmt.Errorf("found %d of %d pixels of graph %s", len(pixels), total, def.ID)
+	}
+	return pixels, nil
 }
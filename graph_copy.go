@@ -0,0 +1,237 @@
+package pixela
+
+import (
+	"context"
+	"fmt"
+)
+
+// Copy creates a graph with the settings of the graph ID, copies its pixels from From to To to the new graph
+// and recreates its webhooks for the new graph. The new graph can belong to another user with Target.
+// Notifications are not copied since their channels belong to the user.
+// Add and subtract webhooks are not copied but reported in SkippedWebhooks,
+// since WebhookDefinition does not have their quantity.
+//
+// If DeleteSource is true, Copy compares the pixels of the new graph with the source after copying,
+// and deletes the source graph and its webhooks only if the new graph has all the pixels of the source,
+// which migrates the graph. A copy of a part of the period is never deleted.
+// The source graph is not migrated either if it has add or subtract webhooks, which would be lost,
+// and Copy returns an error before creating the new graph.
+// If a later step fails, Copy returns the result so far with the error. The source graph is kept,
+// and the new graph is left as it is, so delete it or copy the rest to it.
+func (g *Graph) Copy(ctx context.Context, input *GraphCopyInput) (*GraphCopyResult, error) {
+	if err := input.Validate(); err != nil {
+		return &GraphCopyResult{}, err
+	}
+
+	source := g.failing()
+	target := source
+	if input.Target != nil {
+		target = input.Target.failing()
+	}
+	newID := StringValue(input.NewID)
+	if newID == "" {
+		newID = StringValue(input.ID)
+	}
+	if target.UserName == source.UserName && target.baseURL == source.baseURL && newID == StringValue(input.ID) {
+		v := newValidator("GraphCopyInput")
+		v.add("NewID", "must differ from ID to copy a graph of the same user")
+		return &GraphCopyResult{}, v.err()
+	}
+	def, err := source.GetWithContext(ctx, &GraphGetInput{ID: input.ID})
+	if err != nil {
+		return &GraphCopyResult{}, fmt.Errorf("failed to get graph: %w", err)
+	}
+	webhooks, err := source.webhook().GetAllWithContext(ctx)
+	if err != nil {
+		return &GraphCopyResult{}, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	var toCopy, skipped []WebhookDefinition
+	for _, w := range webhooks.Webhooks {
+		if w.GraphID != StringValue(input.ID) {
+			continue
+		}
+		if err := checkRecreatable(w); err != nil {
+			if input.DeleteSource {
+				return &GraphCopyResult{}, fmt.Errorf("failed to migrate graph: %w", err)
+			}
+			skipped = append(skipped, w)
+			continue
+		}
+		toCopy = append(toCopy, w)
+	}
+	newDef := *def
+	newDef.ID = newID
+	if err := target.createLike(ctx, &newDef); err != nil {
+		return &GraphCopyResult{}, fmt.Errorf("failed to create graph %s: %w", newID, err)
+	}
+
+	result := &GraphCopyResult{WebhookHashes: map[string]string{}, SkippedWebhooks: skipped}
+	copied := map[string]PixelWithBody{}
+	var readErr error
+	pixels := func(yield func(PixelInput) bool) {
+		for p, err := range source.pixelsOf(ctx, def, input.From, input.To, input.Concurrency) {
+			if err != nil {
+				readErr = err
+				return
+			}
+			copied[p.Date] = p
+			if !yield(p.input()) {
+				return
+			}
+		}
+	}
+	writer := target.BulkWriter(&BulkWriterInput{GraphID: &newID, GraphType: String(def.Type), Concurrency: input.Concurrency, Progress: input.Progress})
+	result.Pixels, err = writer.Write(ctx, pixels)
+	if readErr != nil {
+		return result, fmt.Errorf("failed to get pixels: %w", readErr)
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to write pixels: %w", err)
+	}
+	if result.Pixels.Failed > 0 {
+		return result, fmt.Errorf("failed to write %d pixels: %w", result.Pixels.Failed, result.Pixels.Failures[0].Err)
+	}
+
+	var sourceHashes []string
+	for _, w := range toCopy {
+		created, err := target.webhook().CreateWithContext(ctx, &WebhookCreateInput{GraphID: &newID, Type: String(w.Type)})
+		if err != nil {
+			return result, fmt.Errorf("failed to create webhook for %s: %w", w.WebhookHash, err)
+		}
+		result.WebhookHashes[w.WebhookHash] = created.WebhookHash
+		sourceHashes = append(sourceHashes, w.WebhookHash)
+	}
+
+	if !input.DeleteSource {
+		return result, nil
+	}
+	if err := source.verifySource(ctx, def, len(copied)); err != nil {
+		return result, err
+	}
+	if err := target.verifyCopy(ctx, &newDef, copied, input.Concurrency); err != nil {
+		return result, err
+	}
+	for _, hash := range sourceHashes {
+		if _, err := source.webhook().DeleteWithContext(ctx, &WebhookDeleteInput{WebhookHash: String(hash)}); err != nil {
+			return result, fmt.Errorf("failed to delete webhook %s: %w", hash, err)
+		}
+	}
+	if _, err := source.DeleteWithContext(ctx, &GraphDeleteInput{ID: input.ID}); err != nil {
+		return result, fmt.Errorf("failed to delete graph: %w", err)
+	}
+	result.SourceDeleted = true
+	return result, nil
+}
+
+// GraphCopyInput is input of Graph.Copy().
+type GraphCopyInput struct {
+	// ID is a required field
+	ID *string
+	// NewID is the ID of the new graph (default: ID). It must differ from ID unless Target is another user.
+	NewID *string
+	// Target is the Graph API client of the user of the new graph (default: the user of the source graph).
+	Target *Graph
+	// From is the first date of the pixels to copy in yyyyMMdd format (default: the first pixel).
+	From *string
+	// To is the last date of the pixels to copy in yyyyMMdd format (default: the last pixel, even after today).
+	To *string
+	// DeleteSource makes Copy delete the source graph after verifying the copy.
+	DeleteSource bool
+	// Concurrency is the number of requests sent at a time (default: 1).
+	Concurrency int
+	// Progress is called with the progress of writing the pixels.
+	Progress func(BulkProgress)
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *GraphCopyInput) Validate() error {
+	v := newValidator("GraphCopyInput")
+	v.field("ID", in.ID).required().id()
+	v.field("NewID", in.NewID).id()
+	v.field("From", in.From).date()
+	v.field("To", in.To).date()
+	if in.Concurrency < 0 {
+		v.add("Concurrency", "must not be negative")
+	}
+	return v.err()
+}
+
+// GraphCopyResult is the result of Graph.Copy().
+type GraphCopyResult struct {
+	// Pixels is the report of writing the pixels to the new graph.
+	Pixels *BulkReport
+	// WebhookHashes maps the hashes of the webhooks of the source graph to the hashes of the new webhooks.
+	WebhookHashes map[string]string
+	// SkippedWebhooks are the add and subtract webhooks of the source graph that were not copied.
+	SkippedWebhooks []WebhookDefinition
+	// SourceDeleted reports whether the source graph was deleted.
+	SourceDeleted bool
+}
+
+// failing returns a copy of the graph client whose API methods return an *APIError when the API call fails.
+func (g *Graph) failing() *Graph {
+	r := *g.requester
+	r.errorOnFailure = true
+	graph := *g
+	graph.requester = &r
+	return &graph
+}
+
+func (g *Graph) webhook() *Webhook {
+	return &Webhook{UserName: g.UserName, Token: g.Token, requester: g.requester, baseURL: g.baseURL}
+}
+
+// createLike creates a graph with the ID and the settings of def.
+func (g *Graph) createLike(ctx context.Context, def *GraphDefinition) error {
+	input := &GraphCreateInput{
+		ID:                  String(def.ID),
+		Name:                String(def.Name),
+		Unit:                String(def.Unit),
+		Type:                String(def.Type),
+		Color:               String(def.Color),
+		TimeZone:            nonEmpty(def.TimeZone),
+		SelfSufficient:      nonEmpty(def.SelfSufficient),
+		IsSecret:            Bool(def.IsSecret),
+		PublishOptionalData: Bool(def.PublishOptionalData),
+		StartOnMonday:       Bool(def.StartOnMonday),
+	}
+	if _, err := g.CreateWithContext(ctx, input); err != nil {
+		return err
+	}
+	if len(def.PurgeCacheURLs) == 0 {
+		return nil
+	}
+	_, err := g.UpdateWithContext(ctx, &GraphUpdateInput{ID: String(def.ID), PurgeCacheURLs: def.PurgeCacheURLs})
+	return err
+}
+
+// verifySource returns an error unless the source graph of def has copied pixels in total,
+// so that the source is not deleted when a pixel outside the copied period would be lost.
+func (g *Graph) verifySource(ctx context.Context, def *GraphDefinition, copied int) error {
+	stats, err := g.StatsWithContext(ctx, &GraphStatsInput{ID: &def.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get stats of graph %s: %w", def.ID, err)
+	}
+	if stats.TotalPixelsCount != copied {
+		return fmt.Errorf("copied %d of %d pixels of graph %s, so the source is kept", copied, stats.TotalPixelsCount, def.ID)
+	}
+	return nil
+}
+
+// verifyCopy returns an error unless all the pixels of the graph of def are the same as expect.
+func (g *Graph) verifyCopy(ctx context.Context, def *GraphDefinition, expect map[string]PixelWithBody, concurrency int) error {
+	found := 0
+	for p, err := range g.pixelsOf(ctx, def, nil, nil, concurrency) {
+		if err != nil {
+			return fmt.Errorf("failed to get pixels of graph %s: %w", def.ID, err)
+		}
+		if e, ok := expect[p.Date]; !ok || e != p {
+			return fmt.Errorf("graph %s differs from the source at %s", def.ID, p.Date)
+		}
+		found++
+	}
+	if found != len(expect) {
+		return fmt.Errorf("graph %s has %d of %d pixels of the source", def.ID, found, len(expect))
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package pixela
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ebc-2in2crc/pixela4go/pixelatest"
+)
+
+func TestGraph_CopyAndDeleteSource(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	pixels := append(bulkTestPixels(3), PixelInput{Date: String("20220101"), Quantity: String("9"), OptionalData: String(`{"a":1}`)})
+	if _, err := client.Graph().UpdatePixels(&GraphUpdatePixelsInput{ID: String(graphID), Pixels: pixels}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	webhook, err := client.Webhook().Create(&WebhookCreateInput{GraphID: String(graphID), Type: String(WebhookTypeIncrement)})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	input := &GraphCopyInput{ID: String(graphID), NewID: String("new-graph"), From: String("20200101"), DeleteSource: true}
+	result, err := client.Graph().Copy(context.Background(), input)
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if result.Pixels.Written != 4 || result.SourceDeleted == false {
+		t.Errorf("got: %+v, %v\nwant: 4 pixels written and the source deleted", result.Pixels.BulkProgress, result.SourceDeleted)
+	}
+	for _, p := range pixels {
+		if q, _ := srv.Quantity(userName, "new-graph", *p.Date); q != *p.Quantity {
+			t.Errorf("got: %s\nwant: %s", q, *p.Quantity)
+		}
+	}
+	if def, _ := client.Graph().Get(&GraphGetInput{ID: String(graphID)}); def.IsSuccess {
+		t.Errorf("got: %+v\nwant: the source graph deleted", def)
+	}
+	webhooks, err := client.Webhook().GetAll()
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := []WebhookDefinition{{WebhookHash: result.WebhookHashes[webhook.WebhookHash], GraphID: "new-graph", Type: WebhookTypeIncrement}}
+	if !slices.Equal(webhooks.Webhooks, expect) {
+		t.Errorf("got: %+v\nwant: %+v", webhooks.Webhooks, expect)
+	}
+}
+
+func TestGraph_CopyFullHistory(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	future := time.Now().AddDate(0, 0, 10).Format(dateLayout)
+	dates := []string{"19990101", "20240101", future}
+	for _, date := range dates {
+		if _, err := client.Pixel().Create(&PixelCreateInput{GraphID: String(graphID), Date: String(date), Quantity: String("1")}); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+
+	result, err := client.Graph().Copy(context.Background(), &GraphCopyInput{ID: String(graphID), NewID: String("new-graph"), DeleteSource: true})
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if result.Pixels.Written != 3 || result.SourceDeleted == false {
+		t.Errorf("got: %+v, %v\nwant: 3 pixels written and the source deleted", result.Pixels.BulkProgress, result.SourceDeleted)
+	}
+	for _, date := range dates {
+		if q, _ := srv.Quantity(userName, "new-graph", date); q != "1" {
+			t.Errorf("%s: got: %s\nwant: %s", date, q, "1")
+		}
+	}
+}
+
+func TestGraph_CopyPartAndDeleteSource(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	pixels := append(bulkTestPixels(2), PixelInput{Date: String("20190101"), Quantity: String("9")})
+	if _, err := client.Graph().UpdatePixels(&GraphUpdatePixelsInput{ID: String(graphID), Pixels: pixels}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	input := &GraphCopyInput{ID: String(graphID), NewID: String("new-graph"), From: String("20200101"), DeleteSource: true}
+	result, err := client.Graph().Copy(context.Background(), input)
+
+	if err == nil {
+		t.Fatalf("got: nil\nwant: an error")
+	}
+	if result.Pixels.Written != 2 || result.SourceDeleted {
+		t.Errorf("got: %+v, %v\nwant: 2 pixels written and the source kept", result.Pixels.BulkProgress, result.SourceDeleted)
+	}
+	if q, _ := srv.Quantity(userName, graphID, "20190101"); q != "9" {
+		t.Errorf("got: %s\nwant: %s", q, "9")
+	}
+}
+
+func TestGraph_CopyToAnotherUser(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	if _, err := client.Graph().UpdatePixels(&GraphUpdatePixelsInput{ID: String(graphID), Pixels: bulkTestPixels(2)}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	other := New("other", "thisissecret", WithBaseURL(srv.URL))
+	if _, err := other.User().Create(&UserCreateInput{AgreeTermsOfService: Bool(true), NotMinor: Bool(true)}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	result, err := client.Graph().Copy(context.Background(), &GraphCopyInput{ID: String(graphID), Target: other.Graph(), From: String("20200101")})
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if result.Pixels.Written != 2 || result.SourceDeleted {
+		t.Errorf("got: %+v, %v\nwant: 2 pixels written and the source kept", result.Pixels.BulkProgress, result.SourceDeleted)
+	}
+	for _, user := range []string{userName, "other"} {
+		if q, _ := srv.Quantity(user, graphID, "20240102"); q != "2" {
+			t.Errorf("%s: got: %s\nwant: %s", user, q, "2")
+		}
+	}
+}
+
+func createAddWebhook(t *testing.T, srv *pixelatest.Server) string {
+	t.Helper()
+
+	body := strings.NewReader(`{"graphID":"` + graphID + `","type":"add","quantity":"2"}`)
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/v1/users/"+userName+"/webhooks", body)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	req.Header.Set(userToken, "thisissecret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	defer resp.Body.Close()
+	var result WebhookCreateResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || result.WebhookHash == "" {
+		t.Fatalf("got: %+v, %v\nwant: a webhook", result, err)
+	}
+	return result.WebhookHash
+}
+
+func TestGraph_CopyAddWebhook(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	hash := createAddWebhook(t, srv)
+	if _, err := client.Webhook().Create(&WebhookCreateInput{GraphID: String(graphID), Type: String(WebhookTypeIncrement)}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	result, err := client.Graph().Copy(context.Background(), &GraphCopyInput{ID: String(graphID), NewID: String("new-graph")})
+
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if len(result.SkippedWebhooks) != 1 || result.SkippedWebhooks[0].WebhookHash != hash {
+		t.Errorf("got: %+v\nwant: webhook %s skipped", result.SkippedWebhooks, hash)
+	}
+	if len(result.WebhookHashes) != 1 {
+		t.Errorf("got: %v\nwant: the increment webhook copied", result.WebhookHashes)
+	}
+}
+
+func TestGraph_CopyAddWebhookAndDeleteSource(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	createAddWebhook(t, srv)
+
+	input := &GraphCopyInput{ID: String(graphID), NewID: String("new-graph"), DeleteSource: true}
+	_, err := client.Graph().Copy(context.Background(), input)
+
+	if err == nil {
+		t.Fatalf("got: nil\nwant: an error")
+	}
+	if def, _ := client.Graph().Get(&GraphGetInput{ID: String("new-graph")}); def.IsSuccess {
+		t.Errorf("got: %+v\nwant: the new graph not created", def)
+	}
+}
+
+func TestGraph_CopySameGraph(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	_, err := client.Graph().Copy(context.Background(), &GraphCopyInput{ID: String(graphID), Target: client.Graph()})
+
+	if errors.Is(err, ErrInvalidInput) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrInvalidInput)
+	}
+}
@@ -2,11 +2,18 @@ package pixela
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ebc-2in2crc/pixela4go/pixelatest"
 )
 
 func TestGraph_CreateCreateRequestParameter(t *testing.T) {
@@ -428,7 +435,7 @@ func TestGraph_GetSVGFail(t *testing.T) {
 		Mode: String(GraphModeShort),
 	}
 	_, err := client.Graph().GetSVG(input)
-	expect := "failed to do request: failed to call API: " + string(mock.body)
+	expect := "failed to do request: failed to call API: GET " + client.Graph().createGetSVGRequestParameter(input).URL + ": 404: failed."
 	if err == nil {
 		t.Errorf("got: nil\nwant: %s", expect)
 	}
@@ -436,6 +443,14 @@ func TestGraph_GetSVGFail(t *testing.T) {
 	if err != nil && err.Error() != expect {
 		t.Errorf("got: %s\nwant: %s", err.Error(), expect)
 	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) == false {
+		t.Fatalf("got: %T\nwant: *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "failed." {
+		t.Errorf("got: %+v\nwant: 404 failed.", apiErr)
+	}
 }
 
 func TestGraph_CreateUpdatePixelsRequestParameter(t *testing.T) {
@@ -570,19 +585,97 @@ func TestGraph_Stats(t *testing.T) {
 
 	expect := &Stats{
 		TotalPixelsCount:  1,
-		MaxQuantity:       2,
+		MaxQuantity:       "2",
 		MaxDate:           "2023-09-01",
-		MinQuantity:       3,
+		MinQuantity:       "3",
 		MinDate:           "2023-09-02",
-		TotalQuantity:     4,
+		TotalQuantity:     "4",
 		AvgQuantity:       5.0,
-		TodaysQuantity:    6,
-		YesterdayQuantity: 66,
+		TodaysQuantity:    "6",
+		YesterdayQuantity: "66",
 		Result:            Result{IsSuccess: true, StatusCode: http.StatusOK},
 	}
 	if *stats != *expect {
 		t.Errorf("got: %v\nwant: %v", stats, expect)
 	}
+	if total, err := stats.TotalQuantity.Int(); err != nil || total != 4 {
+		t.Errorf("got: %d, %v\nwant: 4, nil", total, err)
+	}
+	if stats.GraphType != "" {
+		t.Errorf("got: %s\nwant: empty without WithGraphType", stats.GraphType)
+	}
+}
+
+func TestGraph_StatsFloat(t *testing.T) {
+	s := `{"totalPixelsCount":2,"maxQuantity":1.25,"maxDate":"2023-09-01","minQuantity":0.1,"minDate":"2023-09-02","totalQuantity":1.35,"avgQuantity":0.675,"todaysQuantity":"1.25","yesterdayQuantity":12345678901234567890.123}`
+	client := New(userName, token)
+	client.HTTPClient = &httpClientMock{statusCode: http.StatusOK, body: []byte(s)}
+	stats, err := client.Graph().Stats(&GraphStatsInput{ID: String(graphID)})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	if stats.TotalQuantity != "1.35" || stats.TodaysQuantity != "1.25" || stats.YesterdayQuantity != "12345678901234567890.123" {
+		t.Errorf("got: %+v\nwant: lossless quantities", stats)
+	}
+	if q, err := stats.MaxQuantity.Float(); err != nil || q != 1.25 {
+		t.Errorf("got: %v, %v\nwant: 1.25, nil", q, err)
+	}
+	if _, err := stats.MaxQuantity.Int(); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+
+	b, err := json.Marshal(stats)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if strings.Contains(string(b), `"yesterdayQuantity":12345678901234567890.123`) == false {
+		t.Errorf("got: %s\nwant: quantities as numbers", b)
+	}
+}
+
+func TestGraph_StatsMissingFields(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = &httpClientMock{statusCode: http.StatusOK, body: []byte(`{"totalPixelsCount":0,"maxQuantity":null}`)}
+	stats, err := client.Graph().Stats(&GraphStatsInput{ID: String(graphID)})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	if stats.MaxQuantity != "" || stats.TotalQuantity != "" {
+		t.Errorf("got: %+v\nwant: empty quantities", stats)
+	}
+	if total, err := stats.TotalQuantity.Int(); err != nil || total != 0 {
+		t.Errorf("got: %d, %v\nwant: 0, nil", total, err)
+	}
+	if q, err := stats.MaxQuantity.Float(); err != nil || q != 0 {
+		t.Errorf("got: %v, %v\nwant: 0, nil", q, err)
+	}
+}
+
+func TestGraph_StatsWithGraphType(t *testing.T) {
+	_, client := newBulkTestClient(t)
+	stats, err := client.Graph().Stats(&GraphStatsInput{ID: String(graphID), WithGraphType: true})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	if stats.GraphType != GraphTypeInt {
+		t.Errorf("got: %s\nwant: %s", stats.GraphType, GraphTypeInt)
+	}
+}
+
+func TestGraph_StatsRequestError(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	srv.Close()
+	stats, err := client.Graph().Stats(&GraphStatsInput{ID: String(graphID)})
+
+	if err == nil {
+		t.Errorf("got: nil\nwant: an error")
+	}
+	if stats == nil || *stats != (Stats{}) {
+		t.Errorf("got: %+v\nwant: empty stats", stats)
+	}
 }
 
 func TestGraph_StatsFail(t *testing.T) {
@@ -854,6 +947,192 @@ func TestGraph_GetPixelDatesError(t *testing.T) {
 	testPageNotFoundError(t, err)
 }
 
+func TestPixels_Dates(t *testing.T) {
+	pixels := &Pixels{Pixels: []string{"20180101"}}
+	if reflect.DeepEqual(pixels.Dates(), []string{"20180101"}) == false {
+		t.Errorf("got: %v\nwant: %v", pixels.Dates(), []string{"20180101"})
+	}
+	if pixels.WithBody() != nil {
+		t.Errorf("got: %v\nwant: nil", pixels.WithBody())
+	}
+
+	pixels = &Pixels{Pixels: []PixelWithBody{{Date: "20180101", Quantity: "1"}}}
+	if reflect.DeepEqual(pixels.WithBody(), []PixelWithBody{{Date: "20180101", Quantity: "1"}}) == false {
+		t.Errorf("got: %v\nwant: %v", pixels.WithBody(), []PixelWithBody{{Date: "20180101", Quantity: "1"}})
+	}
+	if pixels.Dates() != nil {
+		t.Errorf("got: %v\nwant: nil", pixels.Dates())
+	}
+}
+
+func TestPixelWithBody_Accessors(t *testing.T) {
+	pixel := PixelWithBody{Date: "20240414", Quantity: "1.25"}
+
+	tm, err := pixel.Time()
+	if err != nil || tm.Equal(time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC)) == false {
+		t.Errorf("got: %v, %v\nwant: 2024-04-14 UTC", tm, err)
+	}
+
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	tm, err = pixel.TimeIn(tokyo)
+	if err != nil || tm.Equal(time.Date(2024, 4, 14, 0, 0, 0, 0, tokyo)) == false {
+		t.Errorf("got: %v, %v\nwant: 2024-04-14 Asia/Tokyo", tm, err)
+	}
+
+	f, err := pixel.Float()
+	if err != nil || f != 1.25 {
+		t.Errorf("got: %v, %v\nwant: 1.25", f, err)
+	}
+
+	_, err = pixel.Int()
+	if err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+
+	_, err = PixelWithBody{Date: "2024-04-14"}.Time()
+	if err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+}
+
+func TestGraphPixel_Accessors(t *testing.T) {
+	pixel := &GraphPixel{Date: "20240414", Quantity: "5"}
+
+	tm, err := pixel.Time()
+	if err != nil || tm.Equal(time.Date(2024, 4, 14, 0, 0, 0, 0, time.UTC)) == false {
+		t.Errorf("got: %v, %v\nwant: 2024-04-14 UTC", tm, err)
+	}
+
+	i, err := pixel.Int()
+	if err != nil || i != 5 {
+		t.Errorf("got: %v, %v\nwant: 5", i, err)
+	}
+
+	f, err := pixel.Float()
+	if err != nil || f != 5 {
+		t.Errorf("got: %v, %v\nwant: 5", f, err)
+	}
+}
+
+func TestGraphDefinition_Date(t *testing.T) {
+	d := time.Date(2024, 4, 14, 23, 30, 0, 0, time.UTC)
+	params := []struct {
+		timeZone string
+		expect   string
+	}{
+		{timeZone: "", expect: "20240414"},
+		{timeZone: "UTC", expect: "20240414"},
+		{timeZone: "Asia/Tokyo", expect: "20240415"},
+	}
+
+	for _, p := range params {
+		definition := &GraphDefinition{TimeZone: p.timeZone}
+		date, err := definition.Date(d)
+		if err != nil {
+			t.Errorf("got: %v\nwant: nil", err)
+		}
+		if StringValue(date) != p.expect {
+			t.Errorf("got: %s\nwant: %s", StringValue(date), p.expect)
+		}
+	}
+
+	definition := &GraphDefinition{TimeZone: "Invalid/TimeZone"}
+	if _, err := definition.Date(d); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+}
+
+func TestGraph_GetPixelDateList(t *testing.T) {
+	s := `{"pixels":["20180101","20180331"]}`
+	b := []byte(s)
+	client := New(userName, token)
+	client.HTTPClient = &httpClientMock{statusCode: http.StatusOK, body: b}
+	input := &GraphGetPixelDatesInput{ID: String(graphID), From: String("20180101"), To: String("20181231"), WithBody: Bool(true)}
+	list, err := client.Graph().GetPixelDateList(input)
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+
+	expect := &PixelDateList{
+		Dates:  []string{"20180101", "20180331"},
+		Result: Result{IsSuccess: true, StatusCode: http.StatusOK},
+	}
+	if reflect.DeepEqual(list, expect) == false {
+		t.Errorf("got: %v\nwant: %v", list, expect)
+	}
+
+	times, err := list.Times()
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+	expectTimes := []time.Time{
+		time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if reflect.DeepEqual(times, expectTimes) == false {
+		t.Errorf("got: %v\nwant: %v", times, expectTimes)
+	}
+
+	if BoolValue(input.WithBody) == false {
+		t.Errorf("input.WithBody got: false\nwant: true")
+	}
+}
+
+func TestPixelDateList_TimesError(t *testing.T) {
+	list := &PixelDateList{Dates: []string{"2018-01-01"}}
+	_, err := list.Times()
+	if err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+}
+
+func TestGraph_GetPixelDateListError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	input := &GraphGetPixelDatesInput{ID: String(graphID)}
+	_, err := client.Graph().GetPixelDateList(input)
+
+	testPageNotFoundError(t, err)
+}
+
+func TestGraph_GetPixelsWithBody(t *testing.T) {
+	s := `{"pixels":[{"date":"20180331","quantity":"1","optionalData":"{\"key\":\"value\"}"}]}`
+	b := []byte(s)
+	client := New(userName, token)
+	client.HTTPClient = &httpClientMock{statusCode: http.StatusOK, body: b}
+	input := &GraphGetPixelDatesInput{ID: String(graphID), From: String("20180101"), To: String("20181231")}
+	pixels, err := client.Graph().GetPixelsWithBody(input)
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+
+	expect := &PixelsWithBody{
+		Pixels: []PixelWithBody{
+			{
+				Date:         "20180331",
+				Quantity:     "1",
+				OptionalData: "{\"key\":\"value\"}",
+			},
+		},
+		Result: Result{IsSuccess: true, StatusCode: http.StatusOK},
+	}
+	if reflect.DeepEqual(pixels, expect) == false {
+		t.Errorf("got: %v\nwant: %v", pixels, expect)
+	}
+}
+
+func TestGraph_GetPixelsWithBodyFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	input := &GraphGetPixelDatesInput{ID: String(graphID)}
+	result, err := client.Graph().GetPixelsWithBody(input)
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", result)
+	}
+
+	testAPIFailedResult(t, &result.Result, err)
+}
+
 func TestGraph_CreateStopwatchRequestParameter(t *testing.T) {
 	client := New(userName, token)
 	input := &GraphStopwatchInput{ID: String(graphID)}
@@ -1184,3 +1463,78 @@ func TestGraph_AnalyzeError(t *testing.T) {
 
 	testPageNotFoundError(t, err)
 }
+
+func TestPixelDatesWindows(t *testing.T) {
+	windows, err := pixelDatesWindows("20220101", "20240101")
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := []pixelsWindow{
+		{from: "20220101", to: "20221231"},
+		{from: "20230101", to: "20231231"},
+		{from: "20240101", to: "20240101"},
+	}
+	if reflect.DeepEqual(windows, expect) == false {
+		t.Errorf("got: %v\nwant: %v", windows, expect)
+	}
+
+	if _, err := pixelDatesWindows("20240102", "20240101"); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+	if _, err := pixelDatesWindows("", "20240101"); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+}
+
+func TestGraph_AllPixels(t *testing.T) {
+	srv := pixelatest.NewServer()
+	t.Cleanup(srv.Close)
+	client := New(userName, "thisissecret", WithBaseURL(srv.URL))
+	if _, err := client.User().Create(&UserCreateInput{AgreeTermsOfService: Bool(true), NotMinor: Bool(true)}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if _, err := client.Graph().Create(&GraphCreateInput{
+		ID: String(graphID), Name: String("name"), Unit: String("times"), Type: String(GraphTypeInt), Color: String(GraphColorShibafu),
+	}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	dates := []string{"20211231", "20220101", "20221231", "20230101", "20231231", "20240101"}
+	for _, date := range dates {
+		if _, err := client.Pixel().Create(&PixelCreateInput{GraphID: String(graphID), Date: String(date), Quantity: String("1")}); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+
+	for _, concurrency := range []int{0, 3} {
+		input := &GraphAllPixelsInput{ID: String(graphID), From: String("20220101"), To: String("20240101"), Concurrency: concurrency}
+		var got []string
+		for p, err := range client.Graph().AllPixels(context.Background(), input) {
+			if err != nil {
+				t.Fatalf("got: %v\nwant: nil", err)
+			}
+			got = append(got, p.Date)
+		}
+		if reflect.DeepEqual(got, dates[1:]) == false {
+			t.Errorf("got: %v\nwant: %v", got, dates[1:])
+		}
+	}
+
+	input := &GraphAllPixelsInput{ID: String(graphID), From: String("20220101"), To: String("20240101"), Concurrency: 2}
+	var got []string
+	for p := range client.Graph().AllPixels(context.Background(), input) {
+		got = append(got, p.Date)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if reflect.DeepEqual(got, dates[1:3]) == false {
+		t.Errorf("got: %v\nwant: %v", got, dates[1:3])
+	}
+
+	input = &GraphAllPixelsInput{ID: String("unknown"), From: String("20220101"), To: String("20240101")}
+	for _, err := range client.Graph().AllPixels(context.Background(), input) {
+		if errors.Is(err, ErrGraphNotFound) == false {
+			t.Errorf("got: %v\nwant: %v", err, ErrGraphNotFound)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package pixela
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LogLevels are the levels of the logs of API call attempts.
+type LogLevels struct {
+	// Success is the level of successful attempts.
+	Success slog.Level
+	// Retry is the level of failed attempts that will be retried.
+	Retry slog.Level
+	// Failure is the level of failed attempts that will not be retried.
+	Failure slog.Level
+}
+
+// DefaultLogLevels are the LogLevels used unless WithLogLevels is specified.
+var DefaultLogLevels = LogLevels{
+	Success: slog.LevelDebug,
+	Retry:   slog.LevelInfo,
+	Failure: slog.LevelWarn,
+}
+
+// WithLogger makes the client log each attempt of API calls with logger:
+// the method, the URL, the status code, the duration, the attempt number and whether the request was rejected.
+// The X-USER-TOKEN header is never logged, and webhook hashes in URLs are redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithLogLevels sets the levels of the logs written by the logger specified with WithLogger.
+func WithLogLevels(levels LogLevels) Option {
+	return func(c *Client) {
+		c.logLevels = &levels
+	}
+}
+
+// logAttempt logs the attempt-th (0-based) attempt of the request.
+func (r *requester) logAttempt(ctx context.Context, param *requestParameter, m *retryer, attempt int, elapsed time.Duration, retry bool) {
+	levels := DefaultLogLevels
+	if r.logLevels != nil {
+		levels = *r.logLevels
+	}
+
+	level := levels.Success
+	switch {
+	case retry:
+		level = levels.Retry
+	case m.err != nil || m.statusCode >= 300:
+		level = levels.Failure
+	}
+	if !r.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", param.Method),
+		slog.String("url", redactURL(param.URL)),
+		slog.Int("attempt", attempt+1),
+		slog.Duration("duration", elapsed),
+	}
+	if m.err != nil {
+		attrs = append(attrs, slog.String("error", m.err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status", m.statusCode), slog.Bool("isRejected", m.rejected))
+	}
+	attrs = append(attrs, slog.Bool("retry", retry))
+	r.logger.LogAttrs(ctx, level, "pixela API call", attrs...)
+}
+
+// redactURL redacts the password in the URL and webhook hashes,
+// since anyone who knows a webhook hash can invoke the webhook.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == "webhooks" && segments[i] != "" {
+			segments[i] = "REDACTED"
+		}
+	}
+	u.Path = strings.Join(segments, "/")
+	u.RawPath = ""
+	return u.Redacted()
+}
@@ -0,0 +1,75 @@
+package pixela
+
+import (
+	"net/http"
+	"time"
+)
+
+// A Doer sends an HTTP request and returns an HTTP response.
+// Every HTTPClient is a Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// A Middleware wraps a Doer to process every HTTP request and response of API calls,
+// such as logging, header injection and metrics.
+type Middleware func(next Doer) Doer
+
+// chain wraps doer with middlewares. The first middleware is the outermost.
+func chain(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+// LoggingMiddleware returns a Middleware that logs the method, URL, status code and duration of each request with logf.
+// log.Printf can be used as logf. Request headers such as X-USER-TOKEN are not logged, and webhook hashes are redacted.
+func LoggingMiddleware(logf func(format string, v ...any)) Middleware {
+	return TimingMiddleware(func(req *http.Request, resp *http.Response, d time.Duration, err error) {
+		if err != nil {
+			logf("pixela: %s %s: %v (%v)", req.Method, redactURL(req.URL.String()), err, d)
+			return
+		}
+		logf("pixela: %s %s: %d (%v)", req.Method, redactURL(req.URL.String()), resp.StatusCode, d)
+	})
+}
+
+// HeaderMiddleware returns a Middleware that sets header to each request.
+// The values in header replace the values of the same keys.
+func HeaderMiddleware(header http.Header) Middleware {
+	header = header.Clone()
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for k, values := range header {
+				req.Header.Del(k)
+				for _, v := range values {
+					req.Header.Add(k, v)
+				}
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// TimingMiddleware returns a Middleware that calls observe with the duration of each request.
+// resp is nil if err is not nil.
+func TimingMiddleware(observe func(req *http.Request, resp *http.Response, d time.Duration, err error)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+			observe(req, resp, time.Since(start), err)
+			return resp, err
+		})
+	}
+}
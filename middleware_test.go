@@ -0,0 +1,117 @@
+package pixela
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type httpClientRecorder struct {
+	mock     *httpClientMock
+	requests []*http.Request
+}
+
+func (c *httpClientRecorder) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+	return c.mock.Do(req)
+}
+
+func TestWithMiddleware_Order(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next.Do(req)
+				calls = append(calls, name+" after")
+				return resp, err
+			})
+		}
+	}
+	client := New(userName, token, WithHTTPClient(newOKMock()), WithMiddleware(trace("first"), trace("second")))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+	expect := []string{"first before", "second before", "second after", "first after"}
+	if reflect.DeepEqual(calls, expect) == false {
+		t.Errorf("got: %v\nwant: %v", calls, expect)
+	}
+}
+
+func TestWithMiddleware_EveryAttempt(t *testing.T) {
+	count := 0
+	counter := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			count++
+			return next.Do(req)
+		})
+	}
+	mock := &httpClientSequenceMock{responses: []*httpClientMock{newRejectedMock(), newOKMock()}}
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Nanosecond}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy), WithMiddleware(counter))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+	if count != 2 {
+		t.Errorf("got: %d\nwant: %d", count, 2)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	recorder := &httpClientRecorder{mock: newOKMock()}
+	header := http.Header{"x-request-id": {"abc"}, "User-Agent": {"my-app"}}
+	client := New(userName, token, WithHTTPClient(recorder), WithMiddleware(HeaderMiddleware(header)))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+	req := recorder.requests[0]
+	if req.Header.Get("X-Request-Id") != "abc" {
+		t.Errorf("got: %s\nwant: %s", req.Header.Get("X-Request-Id"), "abc")
+	}
+	if req.Header.Get("User-Agent") != "my-app" {
+		t.Errorf("got: %s\nwant: %s", req.Header.Get("User-Agent"), "my-app")
+	}
+	if req.Header.Get(userToken) != token {
+		t.Errorf("got: %s\nwant: %s", req.Header.Get(userToken), token)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var logs []string
+	logf := func(format string, v ...any) { logs = append(logs, fmt.Sprintf(format, v...)) }
+	client := New(userName, token, WithHTTPClient(newAPIFailedMock()), WithMiddleware(LoggingMiddleware(logf)))
+	_, _ = client.User().Delete()
+
+	if len(logs) != 1 {
+		t.Fatalf("got: %v\nwant: 1 log", logs)
+	}
+	expect := fmt.Sprintf("pixela: DELETE %s/users/%s: 404", APIBaseURLForV1, userName)
+	if strings.HasPrefix(logs[0], expect) == false {
+		t.Errorf("got: %s\nwant: %s", logs[0], expect)
+	}
+	if strings.Contains(logs[0], token) {
+		t.Errorf("got: %s\nwant: no token", logs[0])
+	}
+}
+
+func TestTimingMiddleware(t *testing.T) {
+	errNetwork := errors.New("network error")
+	var observed error
+	observe := func(req *http.Request, resp *http.Response, d time.Duration, err error) {
+		observed = err
+		if d < 0 {
+			t.Errorf("got: %v\nwant: >= 0", d)
+		}
+	}
+	mock := &httpClientSequenceMock{responses: []*httpClientMock{nil}, errs: []error{errNetwork}}
+	client := New(userName, token, WithHTTPClient(mock), WithMiddleware(TimingMiddleware(observe)))
+	_, _ = client.User().Delete()
+
+	if errors.Is(observed, errNetwork) == false {
+		t.Errorf("got: %v\nwant: %v", observed, errNetwork)
+	}
+}
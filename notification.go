@@ -0,0 +1,267 @@
+package pixela
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// A Notification manages communication with the Pixela graph notification API.
+type Notification struct {
+	UserName  string
+	Token     string
+	requester *requester
+	baseURL   string
+}
+
+// Specify the target to be notified.
+const (
+	NotificationTargetQuantity = "quantity"
+)
+
+// Specify the condition used to judge whether to notify or not.
+// The quantity is compared with the threshold, or notified when it is a multiple of the threshold.
+const (
+	NotificationConditionGreaterThan = ">"
+	NotificationConditionEqual       = "="
+	NotificationConditionLessThan    = "<"
+	NotificationConditionMultipleOf  = "multipleOf"
+)
+
+// Create creates a notification rule of the graph.
+func (n *Notification) Create(input *NotificationCreateInput) (*Result, error) {
+	return n.CreateWithContext(context.Background(), input)
+}
+
+// CreateWithContext creates a notification rule of the graph.
+func (n *Notification) CreateWithContext(ctx context.Context, input *NotificationCreateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	param, err := n.createCreateRequestParameter(input)
+	if err != nil {
+		return &Result{}, fmt.Errorf("failed to create notification create parameter: %w", err)
+	}
+
+	return doRequestAndParseResponse(ctx, n.requester, param)
+}
+
+// NotificationCreateInput is input of Notification.Create().
+type NotificationCreateInput struct {
+	// GraphID is a required field
+	GraphID *string `json:"-"`
+	// ID is a required field
+	ID *string `json:"id"`
+	// Name is a required field
+	Name *string `json:"name"`
+	// Target is a required field
+	Target *string `json:"target"`
+	// Condition is a required field
+	Condition *string `json:"condition"`
+	// Threshold is a required field
+	Threshold *string `json:"threshold"`
+	// RemindBy is the hour (0-23) to be reminded if the condition is not satisfied by then.
+	RemindBy *string `json:"remindBy,omitempty"`
+	// ChannelID is a required field
+	ChannelID *string `json:"channelID"`
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *NotificationCreateInput) Validate() error {
+	v := newValidator("NotificationCreateInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("ID", in.ID).required().id()
+	v.field("Name", in.Name).required()
+	v.field("Target", in.Target).required().oneOf(NotificationTargetQuantity)
+	v.field("Condition", in.Condition).required().oneOf(notificationConditions...)
+	v.field("Threshold", in.Threshold).required().quantity("")
+	v.field("RemindBy", in.RemindBy).hour()
+	v.field("ChannelID", in.ChannelID).required().id()
+	return v.err()
+}
+
+func (n *Notification) createCreateRequestParameter(input *NotificationCreateInput) (*requestParameter, error) {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return &requestParameter{}, fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	graphID := StringValue(input.GraphID)
+	return &requestParameter{
+		Operation: "pixela.Notification.Create",
+		Method:    http.MethodPost,
+		URL:       n.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/notifications", n.UserName, graphID),
+		Header:    map[string]string{userToken: n.Token},
+		Body:      b,
+	}, nil
+}
+
+// GetAll gets all predefined notification rules of the graph.
+func (n *Notification) GetAll(input *NotificationGetAllInput) (*NotificationDefinitions, error) {
+	return n.GetAllWithContext(context.Background(), input)
+}
+
+// GetAllWithContext gets all predefined notification rules of the graph.
+func (n *Notification) GetAllWithContext(ctx context.Context, input *NotificationGetAllInput) (*NotificationDefinitions, error) {
+	if err := input.Validate(); err != nil {
+		return &NotificationDefinitions{}, err
+	}
+	b, status, err := doRequest(ctx, n.requester, n.createGetAllRequestParameter(input))
+	if err != nil {
+		return &NotificationDefinitions{}, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	var definitions NotificationDefinitions
+	definitions.StatusCode = status
+	if err := json.Unmarshal(b, &definitions); err != nil {
+		return &NotificationDefinitions{}, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+
+	definitions.IsSuccess = definitions.Message == ""
+	return &definitions, nil
+}
+
+// NotificationGetAllInput is input of Notification.GetAll().
+type NotificationGetAllInput struct {
+	// GraphID is a required field
+	GraphID *string
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *NotificationGetAllInput) Validate() error {
+	v := newValidator("NotificationGetAllInput")
+	v.field("GraphID", in.GraphID).required().id()
+	return v.err()
+}
+
+// NotificationDefinitions is notification rule list.
+type NotificationDefinitions struct {
+	Notifications []NotificationDefinition `json:"notifications"`
+	Result
+}
+
+// NotificationDefinition is notification rule definition.
+type NotificationDefinition struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Target    string `json:"target"`
+	Condition string `json:"condition"`
+	Threshold string `json:"threshold"`
+	RemindBy  string `json:"remindBy"`
+	ChannelID string `json:"channelID"`
+}
+
+func (n *Notification) createGetAllRequestParameter(input *NotificationGetAllInput) *requestParameter {
+	graphID := StringValue(input.GraphID)
+	return &requestParameter{
+		Operation: "pixela.Notification.GetAll",
+		Method:    http.MethodGet,
+		URL:       n.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/notifications", n.UserName, graphID),
+		Header:    map[string]string{userToken: n.Token},
+		Body:      []byte{},
+	}
+}
+
+// Update updates the predefined notification rule of the graph.
+func (n *Notification) Update(input *NotificationUpdateInput) (*Result, error) {
+	return n.UpdateWithContext(context.Background(), input)
+}
+
+// UpdateWithContext updates the predefined notification rule of the graph.
+func (n *Notification) UpdateWithContext(ctx context.Context, input *NotificationUpdateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	param, err := n.createUpdateRequestParameter(input)
+	if err != nil {
+		return &Result{}, fmt.Errorf("failed to create notification update parameter: %w", err)
+	}
+
+	return doRequestAndParseResponse(ctx, n.requester, param)
+}
+
+// NotificationUpdateInput is input of Notification.Update().
+type NotificationUpdateInput struct {
+	// GraphID is a required field
+	GraphID *string `json:"-"`
+	// ID is a required field
+	ID        *string `json:"-"`
+	Name      *string `json:"name,omitempty"`
+	Target    *string `json:"target,omitempty"`
+	Condition *string `json:"condition,omitempty"`
+	Threshold *string `json:"threshold,omitempty"`
+	RemindBy  *string `json:"remindBy,omitempty"`
+	ChannelID *string `json:"channelID,omitempty"`
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *NotificationUpdateInput) Validate() error {
+	v := newValidator("NotificationUpdateInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("ID", in.ID).required().id()
+	v.field("Target", in.Target).oneOf(NotificationTargetQuantity)
+	v.field("Condition", in.Condition).oneOf(notificationConditions...)
+	v.field("Threshold", in.Threshold).quantity("")
+	v.field("RemindBy", in.RemindBy).hour()
+	v.field("ChannelID", in.ChannelID).id()
+	return v.err()
+}
+
+func (n *Notification) createUpdateRequestParameter(input *NotificationUpdateInput) (*requestParameter, error) {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return &requestParameter{}, fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	graphID := StringValue(input.GraphID)
+	ID := StringValue(input.ID)
+	return &requestParameter{
+		Operation: "pixela.Notification.Update",
+		Method:    http.MethodPut,
+		URL:       n.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/notifications/%s", n.UserName, graphID, ID),
+		Header:    map[string]string{userToken: n.Token},
+		Body:      b,
+	}, nil
+}
+
+// Delete deletes the predefined notification rule of the graph.
+func (n *Notification) Delete(input *NotificationDeleteInput) (*Result, error) {
+	return n.DeleteWithContext(context.Background(), input)
+}
+
+// DeleteWithContext deletes the predefined notification rule of the graph.
+func (n *Notification) DeleteWithContext(ctx context.Context, input *NotificationDeleteInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, n.requester, n.createDeleteRequestParameter(input))
+}
+
+// NotificationDeleteInput is input of Notification.Delete().
+type NotificationDeleteInput struct {
+	// GraphID is a required field
+	GraphID *string
+	// ID is a required field
+	ID *string
+}
+
+// Validate returns a *ValidationError if the input is invalid.
+func (in *NotificationDeleteInput) Validate() error {
+	v := newValidator("NotificationDeleteInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("ID", in.ID).required().id()
+	return v.err()
+}
+
+func (n *Notification) createDeleteRequestParameter(input *NotificationDeleteInput) *requestParameter {
+	graphID := StringValue(input.GraphID)
+	ID := StringValue(input.ID)
+	return &requestParameter{
+		Operation: "pixela.Notification.Delete",
+		Method:    http.MethodDelete,
+		URL:       n.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/notifications/%s", n.UserName, graphID, ID),
+		Header:    map[string]string{userToken: n.Token},
+		Body:      []byte{},
+	}
+}
@@ -0,0 +1,279 @@
+package pixela
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNotification_CreateCreateRequestParameter(t *testing.T) {
+	client := New(userName, token)
+	input := &NotificationCreateInput{
+		GraphID:   String(graphID),
+		ID:        String("notification-id"),
+		Name:      String("notification-name"),
+		Target:    String(NotificationTargetQuantity),
+		Condition: String(NotificationConditionGreaterThan),
+		Threshold: String("5"),
+		RemindBy:  String("21"),
+		ChannelID: String("channel-id"),
+	}
+	param, err := client.Notification().createCreateRequestParameter(input)
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+
+	if param.Method != http.MethodPost {
+		t.Errorf("request method: %s\nwant: %s", param.Method, http.MethodPost)
+	}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/notifications", userName, graphID)
+	if param.URL != expect {
+		t.Errorf("URL: %s\nwant: %s", param.URL, expect)
+	}
+
+	if param.Header[userToken] != token {
+		t.Errorf("%s: %s\nwant: %s", userToken, param.Header[userToken], token)
+	}
+
+	s := `{"id":"notification-id","name":"notification-name","target":"quantity","condition":"\u003e","threshold":"5","remindBy":"21","channelID":"channel-id"}`
+	b := []byte(s)
+	if bytes.Equal(param.Body, b) == false {
+		t.Errorf("Body: %s\nwant: %s", string(param.Body), s)
+	}
+}
+
+func TestNotification_Create(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newOKMock()
+	input := &NotificationCreateInput{
+		GraphID:   String(graphID),
+		ID:        String("notification-id"),
+		Name:      String("notification-name"),
+		Target:    String(NotificationTargetQuantity),
+		Condition: String(NotificationConditionMultipleOf),
+		Threshold: String("5"),
+		ChannelID: String("channel-id"),
+	}
+	result, err := client.Notification().Create(input)
+
+	testSuccess(t, result, err)
+}
+
+func TestNotification_CreateFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	input := &NotificationCreateInput{
+		GraphID:   String(graphID),
+		ID:        String("notification-id"),
+		Name:      String("notification-name"),
+		Target:    String(NotificationTargetQuantity),
+		Condition: String(NotificationConditionMultipleOf),
+		Threshold: String("5"),
+		ChannelID: String("channel-id"),
+	}
+	result, err := client.Notification().Create(input)
+
+	testAPIFailedResult(t, result, err)
+}
+
+func TestNotification_CreateError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	input := &NotificationCreateInput{
+		GraphID:   String(graphID),
+		ID:        String("notification-id"),
+		Name:      String("notification-name"),
+		Target:    String(NotificationTargetQuantity),
+		Condition: String(NotificationConditionMultipleOf),
+		Threshold: String("5"),
+		ChannelID: String("channel-id"),
+	}
+	_, err := client.Notification().Create(input)
+
+	testPageNotFoundError(t, err)
+}
+
+func TestNotification_CreateGetAllRequestParameter(t *testing.T) {
+	client := New(userName, token)
+	input := &NotificationGetAllInput{GraphID: String(graphID)}
+	param := client.Notification().createGetAllRequestParameter(input)
+
+	if param.Method != http.MethodGet {
+		t.Errorf("request method: %s\nwant: %s", param.Method, http.MethodGet)
+	}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/notifications", userName, graphID)
+	if param.URL != expect {
+		t.Errorf("URL: %s\nwant: %s", param.URL, expect)
+	}
+
+	if param.Header[userToken] != token {
+		t.Errorf("%s: %s\nwant: %s", userToken, param.Header[userToken], token)
+	}
+
+	if bytes.Equal(param.Body, []byte{}) == false {
+		t.Errorf("Body: %s\nwant: \"\"", string(param.Body))
+	}
+}
+
+func TestNotification_GetAll(t *testing.T) {
+	s := `{"notifications":[{"id":"notification-id","name":"notification-name","target":"quantity","condition":">","threshold":"5","remindBy":"21","channelID":"channel-id"}]}`
+	b := []byte(s)
+	client := New(userName, token)
+	client.HTTPClient = &httpClientMock{statusCode: http.StatusOK, body: b}
+	input := &NotificationGetAllInput{GraphID: String(graphID)}
+	definitions, err := client.Notification().GetAll(input)
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+
+	expect := &NotificationDefinitions{
+		Notifications: []NotificationDefinition{
+			{
+				ID:        "notification-id",
+				Name:      "notification-name",
+				Target:    NotificationTargetQuantity,
+				Condition: NotificationConditionGreaterThan,
+				Threshold: "5",
+				RemindBy:  "21",
+				ChannelID: "channel-id",
+			},
+		},
+		Result: Result{IsSuccess: true, StatusCode: http.StatusOK},
+	}
+	if reflect.DeepEqual(definitions, expect) == false {
+		t.Errorf("got: %v\nwant: %v", definitions, expect)
+	}
+}
+
+func TestNotification_GetAllFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	input := &NotificationGetAllInput{GraphID: String(graphID)}
+	result, err := client.Notification().GetAll(input)
+
+	testAPIFailedResult(t, &result.Result, err)
+}
+
+func TestNotification_GetAllError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	input := &NotificationGetAllInput{GraphID: String(graphID)}
+	_, err := client.Notification().GetAll(input)
+
+	testPageNotFoundError(t, err)
+}
+
+func TestNotification_CreateUpdateRequestParameter(t *testing.T) {
+	client := New(userName, token)
+	input := &NotificationUpdateInput{
+		GraphID:   String(graphID),
+		ID:        String("notification-id"),
+		Name:      String("new-name"),
+		Condition: String(NotificationConditionLessThan),
+		Threshold: String("3"),
+	}
+	param, err := client.Notification().createUpdateRequestParameter(input)
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+
+	if param.Method != http.MethodPut {
+		t.Errorf("request method: %s\nwant: %s", param.Method, http.MethodPut)
+	}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/notifications/notification-id", userName, graphID)
+	if param.URL != expect {
+		t.Errorf("URL: %s\nwant: %s", param.URL, expect)
+	}
+
+	if param.Header[userToken] != token {
+		t.Errorf("%s: %s\nwant: %s", userToken, param.Header[userToken], token)
+	}
+
+	s := `{"name":"new-name","condition":"\u003c","threshold":"3"}`
+	b := []byte(s)
+	if bytes.Equal(param.Body, b) == false {
+		t.Errorf("Body: %s\nwant: %s", string(param.Body), s)
+	}
+}
+
+func TestNotification_Update(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newOKMock()
+	input := &NotificationUpdateInput{GraphID: String(graphID), ID: String("notification-id"), Name: String("new-name")}
+	result, err := client.Notification().Update(input)
+
+	testSuccess(t, result, err)
+}
+
+func TestNotification_UpdateFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	input := &NotificationUpdateInput{GraphID: String(graphID), ID: String("notification-id")}
+	result, err := client.Notification().Update(input)
+
+	testAPIFailedResult(t, result, err)
+}
+
+func TestNotification_UpdateError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	input := &NotificationUpdateInput{GraphID: String(graphID), ID: String("notification-id")}
+	_, err := client.Notification().Update(input)
+
+	testPageNotFoundError(t, err)
+}
+
+func TestNotification_CreateDeleteRequestParameter(t *testing.T) {
+	client := New(userName, token)
+	input := &NotificationDeleteInput{GraphID: String(graphID), ID: String("notification-id")}
+	param := client.Notification().createDeleteRequestParameter(input)
+
+	if param.Method != http.MethodDelete {
+		t.Errorf("request method: %s\nwant: %s", param.Method, http.MethodDelete)
+	}
+
+	expect := fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/notifications/notification-id", userName, graphID)
+	if param.URL != expect {
+		t.Errorf("URL: %s\nwant: %s", param.URL, expect)
+	}
+
+	if param.Header[userToken] != token {
+		t.Errorf("%s: %s\nwant: %s", userToken, param.Header[userToken], token)
+	}
+
+	if bytes.Equal(param.Body, []byte{}) == false {
+		t.Errorf("Body: %s\nwant: \"\"", string(param.Body))
+	}
+}
+
+func TestNotification_Delete(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newOKMock()
+	input := &NotificationDeleteInput{GraphID: String(graphID), ID: String("notification-id")}
+	result, err := client.Notification().Delete(input)
+
+	testSuccess(t, result, err)
+}
+
+func TestNotification_DeleteFail(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newAPIFailedMock()
+	input := &NotificationDeleteInput{GraphID: String(graphID), ID: String("notification-id")}
+	result, err := client.Notification().Delete(input)
+
+	testAPIFailedResult(t, result, err)
+}
+
+func TestNotification_DeleteError(t *testing.T) {
+	client := New(userName, token)
+	client.HTTPClient = newPageNotFoundMock()
+	input := &NotificationDeleteInput{GraphID: String(graphID), ID: String("notification-id")}
+	_, err := client.Notification().Delete(input)
+
+	testPageNotFoundError(t, err)
+}
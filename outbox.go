@@ -0,0 +1,458 @@
+package pixela
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// outboxVersion is the version of the file format of Outbox.
+const outboxVersion = 1
+
+// Kinds of OutboxOperation, named after the Pixel methods that send them.
+const (
+	OutboxCreate    = "create"
+	OutboxUpdate    = "update"
+	OutboxAdd       = "add"
+	OutboxSubtract  = "subtract"
+	OutboxIncrement = "increment"
+	OutboxDecrement = "decrement"
+	OutboxDelete    = "delete"
+)
+
+// An Outbox records pixel mutations in a file while the device is offline,
+// and sends them in order with the Pixel API when Replay is called.
+//
+// A recorded mutation is merged into the previous pending mutation of the same pixel when possible:
+// adds and subtracts are summed, and a create or update replaces the previous create or update.
+// Increments and decrements are applied to the day when they were recorded in the timezone of the graph.
+//
+// An Outbox is safe for concurrent use. A file must not be used by more than one Outbox at a time.
+type Outbox struct {
+	client *Client
+	path   string
+	now    func() time.Time
+
+	mu         sync.Mutex
+	state      outboxState
+	inflight   int64
+	replaying  bool
+	lastReplay time.Time
+	lastErr    error
+
+	replayMu sync.Mutex
+}
+
+type outboxState struct {
+	Version int               `json:"version"`
+	NextID  int64             `json:"nextID"`
+	Pending []OutboxOperation `json:"pending"`
+	Failed  []OutboxOperation `json:"failed"`
+}
+
+// OutboxOperation is a pixel mutation recorded in an Outbox.
+type OutboxOperation struct {
+	// ID is the sequence number of the operation in the Outbox.
+	ID int64 `json:"id"`
+	// Kind is the kind of the operation such as OutboxAdd.
+	Kind    string `json:"kind"`
+	GraphID string `json:"graphID"`
+	// Date is the date of the pixel in yyyyMMdd format. It is empty for increments and decrements.
+	Date         string  `json:"date,omitempty"`
+	Quantity     *string `json:"quantity,omitempty"`
+	OptionalData *string `json:"optionalData,omitempty"`
+	// RecordedAt is the time when the operation was recorded.
+	RecordedAt time.Time `json:"recordedAt"`
+	// Merged is the number of operations merged into the operation.
+	Merged int `json:"merged,omitempty"`
+	// Error is the reason why the operation failed. It is set only for failed operations.
+	Error string `json:"error,omitempty"`
+}
+
+// OutboxStatus is the status of an Outbox.
+type OutboxStatus struct {
+	// Pending are the operations waiting to be sent in order.
+	Pending []OutboxOperation
+	// Failed are the operations rejected by Pixela, which are not sent again.
+	Failed []OutboxOperation
+	// Replaying reports whether Replay is running.
+	Replaying bool
+	// LastReplay is the time when the last Replay finished.
+	LastReplay time.Time
+	// LastError is the error of the last Replay.
+	LastError error
+}
+
+// OutboxReplayResult is the result of Outbox.Replay.
+type OutboxReplayResult struct {
+	// Sent is the number of operations sent successfully.
+	Sent int
+	// Failed are the operations rejected by Pixela during the replay.
+	Failed []OutboxOperation
+}
+
+// OpenOutbox opens the Outbox stored in the file at path, or creates it if the file does not exist.
+// The operations are sent with the client.
+func (c *Client) OpenOutbox(path string) (*Outbox, error) {
+	o := &Outbox{client: c.failingClient(), path: path, now: time.Now}
+
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		o.state = outboxState{Version: outboxVersion, NextID: 1}
+		if err := o.save(); err != nil {
+			return nil, err
+		}
+		return o, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &o.state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal json: %w", err)
+	}
+	if o.state.Version != outboxVersion {
+		return nil, fmt.Errorf("unsupported outbox version: %d", o.state.Version)
+	}
+	return o, nil
+}
+
+// Create records Pixel.Create.
+func (o *Outbox) Create(input *PixelCreateInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return o.record(OutboxOperation{Kind: OutboxCreate, GraphID: *input.GraphID, Date: *input.Date, Quantity: input.Quantity, OptionalData: input.OptionalData})
+}
+
+// Update records Pixel.Update.
+func (o *Outbox) Update(input *PixelUpdateInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return o.record(OutboxOperation{Kind: OutboxUpdate, GraphID: *input.GraphID, Date: *input.Date, Quantity: input.Quantity, OptionalData: input.OptionalData})
+}
+
+// Add records Pixel.Add.
+func (o *Outbox) Add(input *PixelAddInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return o.record(OutboxOperation{Kind: OutboxAdd, GraphID: *input.GraphID, Date: *input.Date, Quantity: input.Quantity})
+}
+
+// Subtract records Pixel.Subtract.
+func (o *Outbox) Subtract(input *PixelSubtractInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return o.record(OutboxOperation{Kind: OutboxSubtract, GraphID: *input.GraphID, Date: *input.Date, Quantity: input.Quantity})
+}
+
+// Increment records Pixel.Increment for the day of now.
+func (o *Outbox) Increment(input *PixelIncrementInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return o.record(OutboxOperation{Kind: OutboxIncrement, GraphID: *input.GraphID})
+}
+
+// Decrement records Pixel.Decrement for the day of now.
+func (o *Outbox) Decrement(input *PixelDecrementInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return o.record(OutboxOperation{Kind: OutboxDecrement, GraphID: *input.GraphID})
+}
+
+// Delete records Pixel.Delete.
+func (o *Outbox) Delete(input *PixelDeleteInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return o.record(OutboxOperation{Kind: OutboxDelete, GraphID: *input.GraphID, Date: *input.Date})
+}
+
+// Status returns the status of the Outbox.
+func (o *Outbox) Status() OutboxStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return OutboxStatus{
+		Pending:    slices.Clone(o.state.Pending),
+		Failed:     slices.Clone(o.state.Failed),
+		Replaying:  o.replaying,
+		LastReplay: o.lastReplay,
+		LastError:  o.lastErr,
+	}
+}
+
+// ClearFailed removes the failed operations.
+func (o *Outbox) ClearFailed() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.update(func(s *outboxState) { s.Failed = nil })
+}
+
+// Replay sends the pending operations in order and removes each one once it is sent.
+// An operation rejected by Pixela with a client error such as 400 or 404 is moved to the failed operations
+// and the replay continues. On other errors, such as a network error or a server error,
+// Replay stops and returns the error, keeping the operation pending so that the next Replay resumes from it.
+// Only one Replay runs at a time; operations can be recorded during a replay.
+func (o *Outbox) Replay(ctx context.Context) (*OutboxReplayResult, error) {
+	o.replayMu.Lock()
+	defer o.replayMu.Unlock()
+	o.mu.Lock()
+	o.replaying = true
+	o.mu.Unlock()
+
+	result := &OutboxReplayResult{}
+	err := o.replay(ctx, result)
+
+	o.mu.Lock()
+	o.replaying = false
+	o.lastReplay = o.now()
+	o.lastErr = err
+	o.mu.Unlock()
+	return result, err
+}
+
+func (o *Outbox) replay(ctx context.Context, result *OutboxReplayResult) error {
+	graphs := map[string]*GraphDefinition{}
+	for {
+		o.mu.Lock()
+		if len(o.state.Pending) == 0 {
+			o.mu.Unlock()
+			return nil
+		}
+		op := o.state.Pending[0]
+		o.inflight = op.ID
+		o.mu.Unlock()
+
+		err := o.send(ctx, op, graphs)
+
+		o.mu.Lock()
+		o.inflight = 0
+		if err != nil && !permanentFailure(err) {
+			o.mu.Unlock()
+			return fmt.Errorf("failed to send operation %d: %w", op.ID, err)
+		}
+		saveErr := o.update(func(s *outboxState) {
+			s.Pending = s.Pending[1:]
+			if err != nil {
+				op.Error = err.Error()
+				s.Failed = append(s.Failed, op)
+			}
+		})
+		o.mu.Unlock()
+		if saveErr != nil {
+			return saveErr
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, op)
+		} else {
+			result.Sent++
+		}
+	}
+}
+
+func (o *Outbox) send(ctx context.Context, op OutboxOperation, graphs map[string]*GraphDefinition) error {
+	pixel := o.client.Pixel()
+	graphID, date := String(op.GraphID), String(op.Date)
+	var err error
+	switch op.Kind {
+	case OutboxCreate:
+		_, err = pixel.CreateWithContext(ctx, &PixelCreateInput{GraphID: graphID, Date: date, Quantity: op.Quantity, OptionalData: op.OptionalData})
+	case OutboxUpdate:
+		_, err = pixel.UpdateWithContext(ctx, &PixelUpdateInput{GraphID: graphID, Date: date, Quantity: op.Quantity, OptionalData: op.OptionalData})
+	case OutboxAdd:
+		_, err = pixel.AddWithContext(ctx, &PixelAddInput{GraphID: graphID, Date: date, Quantity: op.Quantity})
+	case OutboxSubtract:
+		_, err = pixel.SubtractWithContext(ctx, &PixelSubtractInput{GraphID: graphID, Date: date, Quantity: op.Quantity})
+	case OutboxIncrement, OutboxDecrement:
+		err = o.sendIncrement(ctx, op, graphs)
+	case OutboxDelete:
+		_, err = pixel.DeleteWithContext(ctx, &PixelDeleteInput{GraphID: graphID, Date: date})
+	default:
+		err = fmt.Errorf("unknown operation kind: %s", op.Kind)
+	}
+	return err
+}
+
+// sendIncrement sends Pixel.Increment or Pixel.Decrement if the operation was recorded today
+// in the timezone of the graph, or adds or subtracts the increment to the pixel of the day otherwise.
+func (o *Outbox) sendIncrement(ctx context.Context, op OutboxOperation, graphs map[string]*GraphDefinition) error {
+	def, ok := graphs[op.GraphID]
+	if !ok {
+		var err error
+		def, err = o.client.Graph().GetWithContext(ctx, &GraphGetInput{ID: String(op.GraphID)})
+		if err != nil {
+			return fmt.Errorf("failed to get graph: %w", err)
+		}
+		graphs[op.GraphID] = def
+	}
+	date, err := def.Date(op.RecordedAt)
+	if err != nil {
+		return err
+	}
+	today, err := def.Date(o.now())
+	if err != nil {
+		return err
+	}
+
+	pixel := o.client.Pixel()
+	graphID := String(op.GraphID)
+	if *date == *today {
+		if op.Kind == OutboxIncrement {
+			_, err = pixel.IncrementWithContext(ctx, &PixelIncrementInput{GraphID: graphID})
+		} else {
+			_, err = pixel.DecrementWithContext(ctx, &PixelDecrementInput{GraphID: graphID})
+		}
+		return err
+	}
+
+	quantity := String("1")
+	if def.Type == GraphTypeFloat {
+		quantity = String("0.01")
+	}
+	if op.Kind == OutboxIncrement {
+		_, err = pixel.AddWithContext(ctx, &PixelAddInput{GraphID: graphID, Date: date, Quantity: quantity})
+	} else {
+		_, err = pixel.SubtractWithContext(ctx, &PixelSubtractInput{GraphID: graphID, Date: date, Quantity: quantity})
+	}
+	return err
+}
+
+// permanentFailure reports whether err is a rejection by Pixela that fails again if the operation is sent again.
+// Authentication errors are not permanent since the token may be fixed.
+func permanentFailure(err error) bool {
+	if errors.Is(err, ErrInvalidInput) {
+		return true
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.IsRejected {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
+
+// record appends op to the pending operations, or merges it into the previous operation of the same pixel.
+func (o *Outbox) record(op OutboxOperation) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	op.RecordedAt = o.now()
+	return o.update(func(s *outboxState) {
+		if i := o.previous(op); i >= 0 {
+			if merged, ok := mergeOperations(s.Pending[i], op); ok {
+				s.Pending[i] = merged
+				return
+			}
+		}
+		op.ID = s.NextID
+		s.NextID++
+		s.Pending = append(s.Pending, op)
+	})
+}
+
+// previous returns the index of the last pending operation that may change the pixel of op,
+// or -1 if there is none or it is being sent.
+func (o *Outbox) previous(op OutboxOperation) int {
+	for i := len(o.state.Pending) - 1; i >= 0; i-- {
+		p := o.state.Pending[i]
+		if p.GraphID != op.GraphID || (p.Date != op.Date && p.Date != "" && op.Date != "") {
+			continue
+		}
+		if p.ID == o.inflight {
+			return -1
+		}
+		return i
+	}
+	return -1
+}
+
+// mergeOperations returns the operation that has the same effect as prev followed by next.
+func mergeOperations(prev, next OutboxOperation) (OutboxOperation, bool) {
+	if prev.Date == "" || next.Date == "" {
+		return OutboxOperation{}, false
+	}
+
+	merged := prev
+	merged.Merged += next.Merged + 1
+	switch {
+	case (prev.Kind == OutboxAdd || prev.Kind == OutboxSubtract) && next.Kind == prev.Kind:
+		quantity, err := addQuantities("", *prev.Quantity, *next.Quantity)
+		if err != nil {
+			return OutboxOperation{}, false
+		}
+		merged.Quantity = &quantity
+	case (prev.Kind == OutboxCreate || prev.Kind == OutboxUpdate) && next.Kind == OutboxCreate:
+		merged.Kind = OutboxCreate
+		merged.Quantity = next.Quantity
+		merged.OptionalData = next.OptionalData
+	case (prev.Kind == OutboxCreate || prev.Kind == OutboxUpdate) && next.Kind == OutboxUpdate:
+		if next.Quantity != nil {
+			merged.Quantity = next.Quantity
+		}
+		if next.OptionalData != nil {
+			merged.OptionalData = next.OptionalData
+		}
+	default:
+		return OutboxOperation{}, false
+	}
+	return merged, true
+}
+
+// update applies f to a copy of the state, saves it and replaces the state if it is saved.
+// o.mu must be held.
+func (o *Outbox) update(f func(s *outboxState)) error {
+	prev := o.state
+	o.state.Pending = slices.Clone(o.state.Pending)
+	o.state.Failed = slices.Clone(o.state.Failed)
+	f(&o.state)
+	if err := o.save(); err != nil {
+		o.state = prev
+		return err
+	}
+	return nil
+}
+
+// save writes the state to a temporary file and renames it to the file of the Outbox,
+// so that the file has either the old or the new state.
+func (o *Outbox) save() error {
+	b, err := json.Marshal(o.state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(o.path), filepath.Base(o.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save outbox: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to save outbox: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to save outbox: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to save outbox: %w", err)
+	}
+	if err := os.Rename(f.Name(), o.path); err != nil {
+		return fmt.Errorf("failed to save outbox: %w", err)
+	}
+	return nil
+}
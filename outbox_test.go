@@ -0,0 +1,126 @@
+package pixela
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOutbox_RecordAndReplay(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	outbox, err := client.OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	now := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+	outbox.now = func() time.Time { return now }
+
+	ops := []func() error{
+		func() error {
+			return outbox.Add(&PixelAddInput{GraphID: String(graphID), Date: String("20240101"), Quantity: String("2")})
+		},
+		func() error {
+			return outbox.Add(&PixelAddInput{GraphID: String(graphID), Date: String("20240101"), Quantity: String("3")})
+		},
+		func() error { return outbox.Increment(&PixelIncrementInput{GraphID: String(graphID)}) },
+		func() error {
+			return outbox.Create(&PixelCreateInput{GraphID: String(graphID), Date: String("20240102"), Quantity: String("1")})
+		},
+		func() error {
+			return outbox.Update(&PixelUpdateInput{GraphID: String(graphID), Date: String("20240102"), OptionalData: String(`{"a":1}`)})
+		},
+		func() error {
+			return outbox.Delete(&PixelDeleteInput{GraphID: String(graphID), Date: String("20231231")})
+		},
+	}
+	for _, op := range ops {
+		if err := op(); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+
+	outbox, err = client.OpenOutbox(path)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	outbox.now = func() time.Time { return now.AddDate(0, 0, 1) }
+	pending := outbox.Status().Pending
+	if len(pending) != 4 {
+		t.Fatalf("got: %+v\nwant: 4 pending operations", pending)
+	}
+	if pending[0].Kind != OutboxAdd || *pending[0].Quantity != "5" || pending[0].Merged != 1 {
+		t.Errorf("got: %+v\nwant: the adds merged", pending[0])
+	}
+	if pending[2].Kind != OutboxCreate || *pending[2].Quantity != "1" || StringValue(pending[2].OptionalData) != `{"a":1}` {
+		t.Errorf("got: %+v\nwant: the update merged into the create", pending[2])
+	}
+
+	result, err := outbox.Replay(context.Background())
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if result.Sent != 3 || len(result.Failed) != 1 || result.Failed[0].Kind != OutboxDelete {
+		t.Errorf("got: %+v\nwant: 3 sent and the delete failed", result)
+	}
+	for date, expect := range map[string]string{"20240101": "5", "20240102": "1", "20240103": "1"} {
+		if q, _ := srv.Quantity(userName, graphID, date); q != expect {
+			t.Errorf("%s: got: %s\nwant: %s", date, q, expect)
+		}
+	}
+	status := outbox.Status()
+	if len(status.Pending) != 0 || len(status.Failed) != 1 || status.Failed[0].Error == "" || status.LastError != nil {
+		t.Errorf("got: %+v\nwant: no pending operations and a failed one", status)
+	}
+
+	if err := outbox.ClearFailed(); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if failed := outbox.Status().Failed; len(failed) != 0 {
+		t.Errorf("got: %+v\nwant: no failed operations", failed)
+	}
+}
+
+func TestOutbox_ReplayRejected(t *testing.T) {
+	srv, client := newBulkTestClient(t)
+	outbox, err := client.OpenOutbox(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	for _, date := range []string{"20240101", "20240102"} {
+		if err := outbox.Add(&PixelAddInput{GraphID: String(graphID), Date: String(date), Quantity: String("1")}); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+
+	srv.RejectNext(1)
+	result, err := outbox.Replay(context.Background())
+	if errors.Is(err, ErrAPICallRejected) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrAPICallRejected)
+	}
+	if result.Sent != 0 || len(outbox.Status().Pending) != 2 || outbox.Status().LastError == nil {
+		t.Errorf("got: %+v, %+v\nwant: all pending", result, outbox.Status())
+	}
+
+	result, err = outbox.Replay(context.Background())
+	if err != nil || result.Sent != 2 {
+		t.Errorf("got: %+v, %v\nwant: 2 sent", result, err)
+	}
+}
+
+func TestOutbox_RecordInvalidInput(t *testing.T) {
+	outbox, err := New(userName, token).OpenOutbox(filepath.Join(t.TempDir(), "outbox.json"))
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	err = outbox.Add(&PixelAddInput{GraphID: String(graphID), Date: String("20240101")})
+
+	if errors.Is(err, ErrInvalidInput) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrInvalidInput)
+	}
+	if pending := outbox.Status().Pending; len(pending) != 0 {
+		t.Errorf("got: %+v\nwant: no pending operations", pending)
+	}
+}
@@ -5,14 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-
 )
 
 // A Pixel manages communication with the Pixela pixel API.
 type Pixel struct {
-	UserName   string
-	Token      string
-	httpClient HTTPClient
+	UserName  string
+	Token     string
+	requester *requester
+	baseURL   string
 }
 
 // Create records the quantity of the specified date as a "Pixel".
@@ -22,12 +22,15 @@ func (p *Pixel) Create(input *PixelCreateInput) (*Result, error) {
 
 // CreateWithContext records the quantity of the specified date as a "Pixel".
 func (p *Pixel) CreateWithContext(ctx context.Context, input *PixelCreateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := p.createCreateRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create pixel create parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, p.httpClient, param)
+	return doRequestAndParseResponse(ctx, p.requester, param)
 }
 
 // PixelCreateInput is input of Pixel.Create().
@@ -41,6 +44,15 @@ type PixelCreateInput struct {
 	OptionalData *string `json:"optionalData,omitempty"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelCreateInput) Validate() error {
+	v := newValidator("PixelCreateInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("Date", in.Date).required().date()
+	v.field("Quantity", in.Quantity).required().quantity("")
+	return v.err()
+}
+
 func (p *Pixel) createCreateRequestParameter(input *PixelCreateInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -49,10 +61,11 @@ func (p *Pixel) createCreateRequestParameter(input *PixelCreateInput) (*requestP
 
 	graphID := StringValue(input.GraphID)
 	return &requestParameter{
-		Method: http.MethodPost,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s", p.UserName, graphID),
-		Header: map[string]string{userToken: p.Token},
-		Body:   b,
+		Operation: "pixela.Pixel.Create",
+		Method:    http.MethodPost,
+		URL:       p.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s", p.UserName, graphID),
+		Header:    map[string]string{userToken: p.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -65,7 +78,10 @@ func (p *Pixel) Increment(input *PixelIncrementInput) (*Result, error) {
 // IncrementWithContext increments quantity "Pixel" of the day (it is used "timezone" setting if Graph's "timezone" is specified, if not specified, calculates it in "UTC").
 // If the graph type is int then 1 added, and for float then 0.01 added.
 func (p *Pixel) IncrementWithContext(ctx context.Context, input *PixelIncrementInput) (*Result, error) {
-	return doRequestAndParseResponse(ctx, p.httpClient, p.createIncrementRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, p.requester, p.createIncrementRequestParameter(input))
 }
 
 // PixelIncrementInput is input of Pixel.Increment().
@@ -74,13 +90,21 @@ type PixelIncrementInput struct {
 	GraphID *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelIncrementInput) Validate() error {
+	v := newValidator("PixelIncrementInput")
+	v.field("GraphID", in.GraphID).required().id()
+	return v.err()
+}
+
 func (p *Pixel) createIncrementRequestParameter(input *PixelIncrementInput) *requestParameter {
 	graphID := StringValue(input.GraphID)
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/increment", p.UserName, graphID),
-		Header: map[string]string{contentLength: "0", userToken: p.Token},
-		Body:   []byte{},
+		Operation: "pixela.Pixel.Increment",
+		Method:    http.MethodPut,
+		URL:       p.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/increment", p.UserName, graphID),
+		Header:    map[string]string{contentLength: "0", userToken: p.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -93,7 +117,10 @@ func (p *Pixel) Decrement(input *PixelDecrementInput) (*Result, error) {
 // DecrementWithContext decrements quantity "Pixel" of the day (it is used "timezone" setting if Graph's "timezone" is specified, if not specified, calculates it in "UTC").
 // If the graph type is int then -1 added, and for float then -0.01 added.
 func (p *Pixel) DecrementWithContext(ctx context.Context, input *PixelDecrementInput) (*Result, error) {
-	return doRequestAndParseResponse(ctx, p.httpClient, p.createDecrementRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, p.requester, p.createDecrementRequestParameter(input))
 }
 
 // PixelDecrementInput is input of Pixel.Decrement().
@@ -102,13 +129,21 @@ type PixelDecrementInput struct {
 	GraphID *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelDecrementInput) Validate() error {
+	v := newValidator("PixelDecrementInput")
+	v.field("GraphID", in.GraphID).required().id()
+	return v.err()
+}
+
 func (p *Pixel) createDecrementRequestParameter(input *PixelDecrementInput) *requestParameter {
 	graphID := StringValue(input.GraphID)
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/decrement", p.UserName, graphID),
-		Header: map[string]string{contentLength: "0", userToken: p.Token},
-		Body:   []byte{},
+		Operation: "pixela.Pixel.Decrement",
+		Method:    http.MethodPut,
+		URL:       p.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/decrement", p.UserName, graphID),
+		Header:    map[string]string{contentLength: "0", userToken: p.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -119,7 +154,10 @@ func (p *Pixel) Get(input *PixelGetInput) (*Quantity, error) {
 
 // GetWithContext gets registered quantity as "Pixel".
 func (p *Pixel) GetWithContext(ctx context.Context, input *PixelGetInput) (*Quantity, error) {
-	b, status, err := doRequest(ctx, p.httpClient, p.createGetRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Quantity{}, err
+	}
+	b, status, err := doRequest(ctx, p.requester, p.createGetRequestParameter(input))
 	if err != nil {
 		return &Quantity{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -142,14 +180,23 @@ type PixelGetInput struct {
 	Date *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelGetInput) Validate() error {
+	v := newValidator("PixelGetInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("Date", in.Date).required().date()
+	return v.err()
+}
+
 func (p *Pixel) createGetRequestParameter(input *PixelGetInput) *requestParameter {
 	graphID := StringValue(input.GraphID)
 	date := StringValue(input.Date)
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/%s", p.UserName, graphID, date),
-		Header: map[string]string{userToken: p.Token},
-		Body:   []byte{},
+		Operation: "pixela.Pixel.Get",
+		Method:    http.MethodGet,
+		URL:       p.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/%s", p.UserName, graphID, date),
+		Header:    map[string]string{userToken: p.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -160,6 +207,16 @@ type Quantity struct {
 	Result
 }
 
+// Int returns Quantity as int64.
+func (q *Quantity) Int() (int64, error) {
+	return parseIntQuantity(q.Quantity)
+}
+
+// Float returns Quantity as float64.
+func (q *Quantity) Float() (float64, error) {
+	return parseFloatQuantity(q.Quantity)
+}
+
 // Update updates the quantity already registered as a "Pixel".
 func (p *Pixel) Update(input *PixelUpdateInput) (*Result, error) {
 	return p.UpdateWithContext(context.Background(), input)
@@ -167,12 +224,15 @@ func (p *Pixel) Update(input *PixelUpdateInput) (*Result, error) {
 
 // UpdateWithContext updates the quantity already registered as a "Pixel".
 func (p *Pixel) UpdateWithContext(ctx context.Context, input *PixelUpdateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := p.createUpdateRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create pixel update parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, p.httpClient, param)
+	return doRequestAndParseResponse(ctx, p.requester, param)
 }
 
 // PixelUpdateInput is input of Pixel.Update().
@@ -185,6 +245,15 @@ type PixelUpdateInput struct {
 	OptionalData *string `json:"optionalData,omitempty"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelUpdateInput) Validate() error {
+	v := newValidator("PixelUpdateInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("Date", in.Date).required().date()
+	v.field("Quantity", in.Quantity).quantity("")
+	return v.err()
+}
+
 func (p *Pixel) createUpdateRequestParameter(input *PixelUpdateInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -194,10 +263,11 @@ func (p *Pixel) createUpdateRequestParameter(input *PixelUpdateInput) (*requestP
 	graphID := StringValue(input.GraphID)
 	date := StringValue(input.Date)
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/%s", p.UserName, graphID, date),
-		Header: map[string]string{userToken: p.Token},
-		Body:   b,
+		Operation: "pixela.Pixel.Update",
+		Method:    http.MethodPut,
+		URL:       p.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/%s", p.UserName, graphID, date),
+		Header:    map[string]string{userToken: p.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -208,12 +278,15 @@ func (p *Pixel) Add(input *PixelAddInput) (*Result, error) {
 
 // AddWithContext adds the specified quantity to the "Pixel" of the specified date.
 func (p *Pixel) AddWithContext(ctx context.Context, input *PixelAddInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := p.createAddRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create pixel add parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, p.httpClient, param)
+	return doRequestAndParseResponse(ctx, p.requester, param)
 }
 
 // PixelAddInput is input of Pixel.Add().
@@ -226,6 +299,15 @@ type PixelAddInput struct {
 	Quantity *string `json:"quantity"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelAddInput) Validate() error {
+	v := newValidator("PixelAddInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("Date", in.Date).required().date()
+	v.field("Quantity", in.Quantity).required().quantity("")
+	return v.err()
+}
+
 func (p *Pixel) createAddRequestParameter(input *PixelAddInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -235,10 +317,11 @@ func (p *Pixel) createAddRequestParameter(input *PixelAddInput) (*requestParamet
 	graphID := StringValue(input.GraphID)
 	date := StringValue(input.Date)
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/%s/add", p.UserName, graphID, date),
-		Header: map[string]string{userToken: p.Token},
-		Body:   b,
+		Operation: "pixela.Pixel.Add",
+		Method:    http.MethodPut,
+		URL:       p.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/%s/add", p.UserName, graphID, date),
+		Header:    map[string]string{userToken: p.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -249,12 +332,15 @@ func (p *Pixel) Subtract(input *PixelSubtractInput) (*Result, error) {
 
 // SubtractWithContext subtracts the specified quantity from the "Pixel" of the specified date.
 func (p *Pixel) SubtractWithContext(ctx context.Context, input *PixelSubtractInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := p.createSubtractRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create pixel subtract parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, p.httpClient, param)
+	return doRequestAndParseResponse(ctx, p.requester, param)
 }
 
 // PixelSubtractInput is input of Pixel.Subtract().
@@ -267,6 +353,15 @@ type PixelSubtractInput struct {
 	Quantity *string `json:"quantity"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelSubtractInput) Validate() error {
+	v := newValidator("PixelSubtractInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("Date", in.Date).required().date()
+	v.field("Quantity", in.Quantity).required().quantity("")
+	return v.err()
+}
+
 func (p *Pixel) createSubtractRequestParameter(input *PixelSubtractInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -276,10 +371,11 @@ func (p *Pixel) createSubtractRequestParameter(input *PixelSubtractInput) (*requ
 	graphID := StringValue(input.GraphID)
 	date := StringValue(input.Date)
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/%s/subtract", p.UserName, graphID, date),
-		Header: map[string]string{userToken: p.Token},
-		Body:   b,
+		Operation: "pixela.Pixel.Subtract",
+		Method:    http.MethodPut,
+		URL:       p.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/%s/subtract", p.UserName, graphID, date),
+		Header:    map[string]string{userToken: p.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -290,7 +386,10 @@ func (p *Pixel) Delete(input *PixelDeleteInput) (*Result, error) {
 
 // DeleteWithContext deletes the registered "Pixel".
 func (p *Pixel) DeleteWithContext(ctx context.Context, input *PixelDeleteInput) (*Result, error) {
-	return doRequestAndParseResponse(ctx, p.httpClient, p.createDeleteRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, p.requester, p.createDeleteRequestParameter(input))
 }
 
 // PixelDeleteInput is input of Pixel.Delete().
@@ -301,13 +400,22 @@ type PixelDeleteInput struct {
 	Date *string `json:"-"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *PixelDeleteInput) Validate() error {
+	v := newValidator("PixelDeleteInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("Date", in.Date).required().date()
+	return v.err()
+}
+
 func (p *Pixel) createDeleteRequestParameter(input *PixelDeleteInput) *requestParameter {
 	graphID := StringValue(input.GraphID)
 	date := StringValue(input.Date)
 	return &requestParameter{
-		Method: http.MethodDelete,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/graphs/%s/%s", p.UserName, graphID, date),
-		Header: map[string]string{userToken: p.Token},
-		Body:   []byte{},
+		Operation: "pixela.Pixel.Delete",
+		Method:    http.MethodDelete,
+		URL:       p.baseURL + fmt.Sprintf("/v1/users/%s/graphs/%s/%s", p.UserName, graphID, date),
+		Header:    map[string]string{userToken: p.Token},
+		Body:      []byte{},
 	}
 }
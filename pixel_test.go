@@ -470,3 +470,25 @@ func TestPixel_DeleteError(t *testing.T) {
 
 	testPageNotFoundError(t, err)
 }
+
+func TestQuantity_Accessors(t *testing.T) {
+	quantity := &Quantity{Quantity: "10"}
+
+	i, err := quantity.Int()
+	if err != nil || i != 10 {
+		t.Errorf("got: %v, %v\nwant: 10", i, err)
+	}
+
+	f, err := quantity.Float()
+	if err != nil || f != 10 {
+		t.Errorf("got: %v, %v\nwant: 10", f, err)
+	}
+
+	quantity = &Quantity{Quantity: ""}
+	if _, err := quantity.Int(); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+	if _, err := quantity.Float(); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+}
@@ -0,0 +1,213 @@
+// Package pixelaotel traces the API calls of pixela clients with OpenTelemetry.
+//
+// Each API operation such as Graph.Stats becomes a span named "pixela.Graph.Stats",
+// and each attempt of the operation, including retries, becomes a child span.
+// It also records metrics of requests, rejections and latency.
+//
+//	tracer, err := pixelaotel.NewTracer()
+//	if err != nil {
+//		return err
+//	}
+//	client := pixela.New(userName, token, pixela.WithTracer(tracer))
+//
+// The pixela package itself does not depend on OpenTelemetry, since this package is a separate module.
+package pixelaotel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and the meter.
+const ScopeName = "github.com/ebc-2in2crc/pixela4go/pixelaotel"
+
+const (
+	// OperationKey is the attribute key of the operation name such as "pixela.Graph.Stats".
+	OperationKey = attribute.Key("pixela.operation")
+	// AttemptsKey is the attribute key of the number of attempts of an operation.
+	AttemptsKey = attribute.Key("pixela.attempts")
+	// RejectedKey is the attribute key of whether Pixela rejected the request.
+	RejectedKey = attribute.Key("pixela.rejected")
+	// RetryKey is the attribute key of whether the operation will be retried after the attempt.
+	RetryKey = attribute.Key("pixela.retry")
+)
+
+// A Tracer is a pixela.Tracer that records spans and metrics with OpenTelemetry.
+//
+// It records the following metrics:
+//   - pixela.client.requests: the number of attempts
+//   - pixela.client.rejections: the number of attempts rejected by Pixela
+//   - pixela.client.request.duration: the duration of each attempt in seconds
+//   - pixela.client.operation.duration: the duration of each operation including retries in seconds
+type Tracer struct {
+	tracer            trace.Tracer
+	requests          metric.Int64Counter
+	rejections        metric.Int64Counter
+	requestDuration   metric.Float64Histogram
+	operationDuration metric.Float64Histogram
+}
+
+var _ pixela.Tracer = (*Tracer)(nil)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// An Option configures a Tracer.
+type Option func(c *config)
+
+// WithTracerProvider sets the TracerProvider (default: otel.GetTracerProvider()).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider (default: otel.GetMeterProvider()).
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// NewTracer returns a new Tracer.
+func NewTracer(opts ...Option) (*Tracer, error) {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	requests, err := meter.Int64Counter("pixela.client.requests",
+		metric.WithDescription("The number of attempts of Pixela API calls."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create counter: %w", err)
+	}
+	rejections, err := meter.Int64Counter("pixela.client.rejections",
+		metric.WithDescription("The number of attempts of Pixela API calls rejected by Pixela."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create counter: %w", err)
+	}
+	requestDuration, err := meter.Float64Histogram("pixela.client.request.duration",
+		metric.WithDescription("The duration of each attempt of Pixela API calls."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create histogram: %w", err)
+	}
+	operationDuration, err := meter.Float64Histogram("pixela.client.operation.duration",
+		metric.WithDescription("The duration of Pixela API calls including retries."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create histogram: %w", err)
+	}
+
+	return &Tracer{
+		tracer:            c.tracerProvider.Tracer(ScopeName),
+		requests:          requests,
+		rejections:        rejections,
+		requestDuration:   requestDuration,
+		operationDuration: operationDuration,
+	}, nil
+}
+
+// StartOperation starts the span of the operation.
+func (t *Tracer) StartOperation(ctx context.Context, op pixela.Operation) (context.Context, func(pixela.OperationResult)) {
+	start := time.Now()
+	ctx, span := t.tracer.Start(ctx, op.Name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			OperationKey.String(op.Name),
+			semconv.HTTPRequestMethodKey.String(op.Method),
+			semconv.URLFull(op.URL),
+		))
+
+	return ctx, func(result pixela.OperationResult) {
+		opt := metricAttributes(op.Name, op.Method, result.StatusCode, result.Err)
+		t.operationDuration.Record(ctx, time.Since(start).Seconds(), opt)
+
+		span.SetAttributes(AttemptsKey.Int(result.Attempts))
+		if result.StatusCode != 0 {
+			span.SetAttributes(semconv.HTTPResponseStatusCode(result.StatusCode))
+		}
+		setStatus(span, result.StatusCode, result.Err)
+		span.End()
+	}
+}
+
+// StartAttempt starts the span of the attempt as a child of the span of the operation.
+func (t *Tracer) StartAttempt(ctx context.Context, attempt pixela.Attempt) (context.Context, func(pixela.AttemptResult)) {
+	start := time.Now()
+	attrs := []attribute.KeyValue{
+		OperationKey.String(attempt.Name),
+		semconv.HTTPRequestMethodKey.String(attempt.Method),
+		semconv.URLFull(attempt.URL),
+	}
+	if attempt.Number > 1 {
+		attrs = append(attrs, semconv.HTTPRequestResendCount(attempt.Number-1))
+	}
+	ctx, span := t.tracer.Start(ctx, attempt.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	return ctx, func(result pixela.AttemptResult) {
+		opt := metricAttributes(attempt.Name, attempt.Method, result.StatusCode, result.Err)
+		t.requests.Add(ctx, 1, opt)
+		if result.IsRejected {
+			t.rejections.Add(ctx, 1, opt)
+		}
+		t.requestDuration.Record(ctx, time.Since(start).Seconds(), opt)
+
+		span.SetAttributes(RejectedKey.Bool(result.IsRejected), RetryKey.Bool(result.Retry))
+		if result.StatusCode != 0 {
+			span.SetAttributes(semconv.HTTPResponseStatusCode(result.StatusCode))
+		}
+		setStatus(span, result.StatusCode, result.Err)
+		span.End()
+	}
+}
+
+func setStatus(span trace.Span, statusCode int, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case statusCode >= 300:
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+}
+
+// metricAttributes returns the attributes of the metrics. error.type is the status code of a failure response,
+// or "error" for an error without a failure response.
+func metricAttributes(operation, method string, statusCode int, err error) metric.MeasurementOption {
+	attrs := []attribute.KeyValue{
+		OperationKey.String(operation),
+		semconv.HTTPRequestMethodKey.String(method),
+	}
+	if statusCode != 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(statusCode))
+	}
+	switch {
+	case statusCode >= 300:
+		attrs = append(attrs, semconv.ErrorTypeKey.String(strconv.Itoa(statusCode)))
+	case err != nil:
+		attrs = append(attrs, semconv.ErrorTypeKey.String("error"))
+	}
+	return metric.WithAttributes(attrs...)
+}
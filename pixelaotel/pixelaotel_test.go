@@ -0,0 +1,191 @@
+package pixelaotel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+	"github.com/ebc-2in2crc/pixela4go/pixelaotel"
+	"github.com/ebc-2in2crc/pixela4go/pixelatest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	userName = "pixelaotel"
+	token    = "thisissecret"
+	graphID  = "test-graph"
+)
+
+func newTracedClient(t *testing.T, opts ...pixela.Option) (*pixelatest.Server, *pixela.Client, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	srv := pixelatest.NewServer()
+	t.Cleanup(srv.Close)
+
+	setup := pixela.New(userName, token, pixela.WithBaseURL(srv.URL), pixela.WithErrorOnFailure())
+	if _, err := setup.User().Create(&pixela.UserCreateInput{AgreeTermsOfService: pixela.Bool(true), NotMinor: pixela.Bool(true)}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	_, err := setup.Graph().Create(&pixela.GraphCreateInput{
+		ID:       pixela.String(graphID),
+		Name:     pixela.String("graph-name"),
+		Unit:     pixela.String("times"),
+		Type:     pixela.String(pixela.GraphTypeInt),
+		Color:    pixela.String(pixela.GraphColorShibafu),
+		TimeZone: pixela.String("UTC"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create graph: %v", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	tracer, err := pixelaotel.NewTracer(
+		pixelaotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		pixelaotel.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+
+	opts = append([]pixela.Option{pixela.WithBaseURL(srv.URL), pixela.WithTracer(tracer)}, opts...)
+	return srv, pixela.New(userName, token, opts...), recorder, reader
+}
+
+func TestTracer_Retry(t *testing.T) {
+	srv, client, recorder, reader := newTracedClient(t, pixela.WithRetryPolicy(pixela.RetryPolicy{MaxAttempts: 3}))
+	srv.RejectNext(1)
+
+	if _, err := client.Graph().Stats(&pixela.GraphStatsInput{ID: pixela.String(graphID)}); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got: %d spans\nwant: 3 spans", len(spans))
+	}
+	first, second, op := spans[0], spans[1], spans[2]
+	if op.Name() != "pixela.Graph.Stats" {
+		t.Errorf("got: %s\nwant: %s", op.Name(), "pixela.Graph.Stats")
+	}
+	if attr(op.Attributes(), pixelaotel.AttemptsKey).AsInt64() != 2 {
+		t.Errorf("got: %v\nwant: %d", attr(op.Attributes(), pixelaotel.AttemptsKey), 2)
+	}
+	if op.Status().Code != codes.Unset {
+		t.Errorf("got: %v\nwant: %v", op.Status().Code, codes.Unset)
+	}
+	for _, s := range []sdktrace.ReadOnlySpan{first, second} {
+		if s.Name() != "GET" {
+			t.Errorf("got: %s\nwant: %s", s.Name(), "GET")
+		}
+		if s.Parent().SpanID() != op.SpanContext().SpanID() {
+			t.Errorf("got: %v\nwant: %v", s.Parent().SpanID(), op.SpanContext().SpanID())
+		}
+	}
+	if attr(first.Attributes(), pixelaotel.RejectedKey).AsBool() == false {
+		t.Errorf("got: %v\nwant: true", attr(first.Attributes(), pixelaotel.RejectedKey))
+	}
+	if attr(first.Attributes(), pixelaotel.RetryKey).AsBool() == false {
+		t.Errorf("got: %v\nwant: true", attr(first.Attributes(), pixelaotel.RetryKey))
+	}
+	if first.Status().Code != codes.Error {
+		t.Errorf("got: %v\nwant: %v", first.Status().Code, codes.Error)
+	}
+	if attr(second.Attributes(), "http.response.status_code").AsInt64() != 200 {
+		t.Errorf("got: %v\nwant: %d", attr(second.Attributes(), "http.response.status_code"), 200)
+	}
+	if attr(second.Attributes(), "http.request.resend_count").AsInt64() != 1 {
+		t.Errorf("got: %v\nwant: %d", attr(second.Attributes(), "http.request.resend_count"), 1)
+	}
+
+	metrics := collect(t, reader)
+	if got := sum(metrics["pixela.client.requests"]); got != 2 {
+		t.Errorf("got: %d\nwant: %d", got, 2)
+	}
+	if got := sum(metrics["pixela.client.rejections"]); got != 1 {
+		t.Errorf("got: %d\nwant: %d", got, 1)
+	}
+	if got := count(metrics["pixela.client.request.duration"]); got != 2 {
+		t.Errorf("got: %d\nwant: %d", got, 2)
+	}
+	if got := count(metrics["pixela.client.operation.duration"]); got != 1 {
+		t.Errorf("got: %d\nwant: %d", got, 1)
+	}
+}
+
+func TestTracer_Failure(t *testing.T) {
+	_, client, recorder, reader := newTracedClient(t, pixela.WithErrorOnFailure())
+
+	_, err := client.Graph().Stats(&pixela.GraphStatsInput{ID: pixela.String("missing")})
+	if errors.Is(err, pixela.ErrGraphNotFound) == false {
+		t.Fatalf("got: %v\nwant: %v", err, pixela.ErrGraphNotFound)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got: %d spans\nwant: 2 spans", len(spans))
+	}
+	for _, s := range spans {
+		if s.Status().Code != codes.Error {
+			t.Errorf("%s: got: %v\nwant: %v", s.Name(), s.Status().Code, codes.Error)
+		}
+		if attr(s.Attributes(), "http.response.status_code").AsInt64() != 404 {
+			t.Errorf("%s: got: %v\nwant: %d", s.Name(), attr(s.Attributes(), "http.response.status_code"), 404)
+		}
+	}
+
+	metrics := collect(t, reader)
+	dp := metrics["pixela.client.requests"].(metricdata.Sum[int64]).DataPoints[0]
+	if v, _ := dp.Attributes.Value("error.type"); v.AsString() != "404" {
+		t.Errorf("got: %v\nwant: %s", v, "404")
+	}
+}
+
+func attr(attrs []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func sum(data metricdata.Aggregation) int64 {
+	s, _ := data.(metricdata.Sum[int64])
+	var total int64
+	for _, dp := range s.DataPoints {
+		total += dp.Value
+	}
+	return total
+}
+
+func count(data metricdata.Aggregation) uint64 {
+	h, _ := data.(metricdata.Histogram[float64])
+	var total uint64
+	for _, dp := range h.DataPoints {
+		total += dp.Count
+	}
+	return total
+}
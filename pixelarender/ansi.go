@@ -0,0 +1,106 @@
+package pixelarender
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+const (
+	// ansiCell is the character of a pixel, followed by a space.
+	ansiCell = "■"
+	// ansiLeftMargin is the width of the weekday labels.
+	ansiLeftMargin = 4
+	ansiReset      = "\x1b[0m"
+)
+
+// ansiLineLevels are the characters of the line mode from the smallest quantity to the largest one.
+var ansiLineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// ANSI writes the graph of the pixels to w as colored text for terminals with 24-bit color support.
+// Each pixel is a block colored with ANSI escape sequences. The line mode is drawn as a sparkline.
+func ANSI(w io.Writer, def *pixela.GraphDefinition, pixels []pixela.PixelWithBody, opts *Options) error {
+	l, err := newLayout(def, pixels, opts)
+	if err != nil {
+		return err
+	}
+	t, err := newTheme(def.Color, l.appearance)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	switch l.mode {
+	case pixela.GraphModeBadge:
+		fmt.Fprintf(&b, "%s ", def.Name)
+		for _, c := range l.cells {
+			b.WriteString(colored(ansiCell, t.color(c.level)) + " ")
+		}
+		b.WriteString("\n")
+	case pixela.GraphModeLine:
+		writeSparkline(&b, l, t)
+	default:
+		writeHeatmapANSI(&b, l, t)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write text: %w", err)
+	}
+	return nil
+}
+
+func writeHeatmapANSI(b *strings.Builder, l *layout, t *theme) {
+	// A label at the last column overflows the pixels.
+	months := []rune(strings.Repeat(" ", ansiLeftMargin+l.columns*2+len("Jan")))
+	next := 0
+	for _, m := range l.months {
+		at := ansiLeftMargin + m.index*2
+		if at < next {
+			continue
+		}
+		copy(months[at:], []rune(m.text))
+		next = at + len(m.text) + 1
+	}
+	b.WriteString(strings.TrimRight(string(months), " ") + "\n")
+
+	rows := make([][]string, l.rows)
+	for i := range rows {
+		rows[i] = make([]string, l.columns)
+	}
+	for _, c := range l.cells {
+		rows[c.row][c.column] = colored(ansiCell, t.color(c.level))
+	}
+	weekdays := make([]string, l.rows)
+	for _, d := range l.weekdays {
+		weekdays[d.index] = d.text
+	}
+	for i, row := range rows {
+		fmt.Fprintf(b, "%-*s", ansiLeftMargin, weekdays[i])
+		for _, cell := range row {
+			if cell == "" {
+				cell = " "
+			}
+			b.WriteString(cell + " ")
+		}
+		b.WriteString("\n")
+	}
+}
+
+func writeSparkline(b *strings.Builder, l *layout, t *theme) {
+	for _, c := range l.cells {
+		level := 0
+		if l.max > 0 && c.value > 0 {
+			level = min(int(c.value/l.max*float64(len(ansiLineLevels))), len(ansiLineLevels)-1)
+		}
+		b.WriteString(colored(string(ansiLineLevels[level]), t.color(c.level)))
+	}
+	b.WriteString("\n")
+}
+
+// colored returns s in the 24-bit foreground color.
+func colored(s string, c color.RGBA) string {
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm%s%s", c.R, c.G, c.B, s, ansiReset)
+}
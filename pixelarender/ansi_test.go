@@ -0,0 +1,52 @@
+package pixelarender
+
+import (
+	"strings"
+	"testing"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+func renderANSI(t *testing.T, opts *Options) string {
+	t.Helper()
+
+	var b strings.Builder
+	if err := ANSI(&b, newTestDefinition(), testPixels, opts); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	return b.String()
+}
+
+func TestANSI(t *testing.T) {
+	text := renderANSI(t, &Options{Date: testDate, Mode: pixela.GraphModeShort, LessThan: pixela.String("4")})
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+
+	if len(lines) != 8 {
+		t.Fatalf("got: %d lines\nwant: 8 lines", len(lines))
+	}
+	if expect := strings.Repeat(" ", 10) + "Nov     Dec       Jan"; lines[0] != expect {
+		t.Errorf("got: %q\nwant: %q", lines[0], expect)
+	}
+	if !strings.HasPrefix(lines[2], "Mon ") {
+		t.Errorf("got: %q\nwant: starts with Mon", lines[2])
+	}
+	// 20240101 is hidden by LessThan, and 20240102 has the largest quantity.
+	if expect := "\x1b[38;2;235;237;240m■\x1b[0m \n"; !strings.HasSuffix(lines[2]+"\n", expect) {
+		t.Errorf("got: %q\nwant: ends with %q", lines[2], expect)
+	}
+	if expect := "\x1b[38;2;33;110;57m■\x1b[0m \n"; !strings.HasSuffix(lines[3]+"\n", expect) {
+		t.Errorf("got: %q\nwant: ends with %q", lines[3], expect)
+	}
+}
+
+func TestANSI_BadgeAndLine(t *testing.T) {
+	badge := renderANSI(t, &Options{Date: testDate, Mode: pixela.GraphModeBadge})
+	if !strings.HasPrefix(badge, "name ") || strings.Count(badge, "■") != 7 {
+		t.Errorf("got: %q\nwant: the name and 7 pixels", badge)
+	}
+
+	line := renderANSI(t, &Options{Date: testDate, Mode: pixela.GraphModeLine})
+	if strings.Count(line, "\x1b[38;2;") != 90 || !strings.Contains(line, "\x1b[38;2;33;110;57m█") {
+		t.Errorf("got: %q\nwant: 90 characters with the largest quantity", line)
+	}
+}
@@ -0,0 +1,119 @@
+package pixelarender
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+// Sizes of PNG graphs in pixels.
+const (
+	pngCellSize   = 10
+	pngCellStep   = 12
+	pngMargin     = 4
+	pngLineStep   = 4
+	pngLineHeight = 80
+)
+
+// PNG writes the graph of the pixels to w in PNG.
+// Unlike SVG, the image has no labels since the standard library cannot draw text.
+func PNG(w io.Writer, def *pixela.GraphDefinition, pixels []pixela.PixelWithBody, opts *Options) error {
+	l, err := newLayout(def, pixels, opts)
+	if err != nil {
+		return err
+	}
+	t, err := newTheme(def.Color, l.appearance)
+	if err != nil {
+		return err
+	}
+
+	var img *image.RGBA
+	if l.mode == pixela.GraphModeLine {
+		img = drawLinePNG(l, t)
+	} else {
+		img = drawCellsPNG(l, t)
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("failed to write png: %w", err)
+	}
+	return nil
+}
+
+func drawCellsPNG(l *layout, t *theme) *image.RGBA {
+	width := 2*pngMargin + l.columns*pngCellStep - (pngCellStep - pngCellSize)
+	height := 2*pngMargin + l.rows*pngCellStep - (pngCellStep - pngCellSize)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(t.background), image.Point{}, draw.Src)
+
+	for _, c := range l.cells {
+		x, y := pngMargin+c.column*pngCellStep, pngMargin+c.row*pngCellStep
+		draw.Draw(img, image.Rect(x, y, x+pngCellSize, y+pngCellSize), image.NewUniform(t.color(c.level)), image.Point{}, draw.Src)
+	}
+	return img
+}
+
+func drawLinePNG(l *layout, t *theme) *image.RGBA {
+	width := 2*pngMargin + (l.columns-1)*pngLineStep + 1
+	height := 2*pngMargin + pngLineHeight + 1
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(t.background), image.Point{}, draw.Src)
+
+	base := pngMargin + pngLineHeight
+	drawLine(img, image.Pt(pngMargin, base), image.Pt(width-pngMargin-1, base), t.empty)
+
+	point := func(c cell) image.Point {
+		y := base
+		if l.max > 0 {
+			y -= int(max(c.value, 0) / l.max * pngLineHeight)
+		}
+		return image.Pt(pngMargin+c.column*pngLineStep, y)
+	}
+	for i := 1; i < len(l.cells); i++ {
+		drawLine(img, point(l.cells[i-1]), point(l.cells[i]), t.levels[levels-1])
+	}
+	return img
+}
+
+// drawLine draws a line from p to q with Bresenham's algorithm.
+func drawLine(img *image.RGBA, p, q image.Point, c color.RGBA) {
+	dx, dy := abs(q.X-p.X), -abs(q.Y-p.Y)
+	sx, sy := sign(q.X-p.X), sign(q.Y-p.Y)
+	e := dx + dy
+	for {
+		img.SetRGBA(p.X, p.Y, c)
+		if p == q {
+			return
+		}
+		if 2*e >= dy {
+			e += dy
+			p.X += sx
+		}
+		if 2*e <= dx {
+			e += dx
+			p.Y += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
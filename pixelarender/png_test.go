@@ -0,0 +1,65 @@
+package pixelarender
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+func renderPNG(t *testing.T, opts *Options) image.Image {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := PNG(&buf, newTestDefinition(), testPixels, opts); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	return img
+}
+
+func TestPNG(t *testing.T) {
+	img := renderPNG(t, &Options{Date: testDate, GreaterThan: pixela.String("1")})
+
+	if got := img.Bounds().Size(); got != image.Pt(642, 90) {
+		t.Errorf("got: %v\nwant: %v", got, image.Pt(642, 90))
+	}
+	params := []struct {
+		date   string
+		x, y   int
+		expect string
+	}{
+		{date: "20240101", x: 628, y: 16, expect: "#216e39"},
+		{date: "20240102", x: 628, y: 28, expect: "#ebedf0"},
+		{date: "margin", x: 0, y: 0, expect: "#ffffff"},
+	}
+	for _, p := range params {
+		if got := hex(rgba(img.At(p.x, p.y))); got != p.expect {
+			t.Errorf("%s: got: %s\nwant: %s", p.date, got, p.expect)
+		}
+	}
+}
+
+func TestPNG_Line(t *testing.T) {
+	img := renderPNG(t, &Options{Date: testDate, Mode: pixela.GraphModeLine, Appearance: pixela.GraphAppearanceDark})
+
+	if got := img.Bounds().Size(); got != image.Pt(365, 89) {
+		t.Errorf("got: %v\nwant: %v", got, image.Pt(365, 89))
+	}
+	if got := hex(rgba(img.At(340, 4))); got != "#9be9a8" {
+		t.Errorf("got: %s\nwant: %s", got, "#9be9a8")
+	}
+	if got := hex(rgba(img.At(0, 0))); got != "#0d1117" {
+		t.Errorf("got: %s\nwant: %s", got, "#0d1117")
+	}
+}
+
+func rgba(c color.Color) color.RGBA {
+	return color.RGBAModel.Convert(c).(color.RGBA)
+}
@@ -0,0 +1,286 @@
+// Package pixelarender renders pixela graphs locally from a graph definition and its pixels
+// as SVG, PNG or colored text for terminals, without calling the Pixela API to get the image.
+//
+//	def, err := client.Graph().Get(&pixela.GraphGetInput{ID: pixela.String("graph-id")})
+//	...
+//	pixels, err := client.Graph().GetPixelsWithBody(&pixela.GraphGetPixelDatesInput{ID: pixela.String("graph-id")})
+//	...
+//	err = pixelarender.SVG(w, def, pixels.Pixels, &pixelarender.Options{Mode: pixela.GraphModeShort})
+//
+// The images look like the ones of Pixela, but they are not the same.
+package pixelarender
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strconv"
+	"time"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+// Options are the options of rendering a graph.
+type Options struct {
+	// Date is the last date of the graph (default: today in the timezone of the graph).
+	Date time.Time
+	// Mode is the display mode: pixela.GraphModeShort, pixela.GraphModeBadge or pixela.GraphModeLine.
+	// The default mode shows about a year.
+	Mode string
+	// Appearance is pixela.GraphAppearanceDark for the dark theme.
+	Appearance string
+	// LessThan shows only the pixels whose quantity is less than it, like GraphGetSVGInput.LessThan.
+	LessThan *string
+	// GreaterThan shows only the pixels whose quantity is greater than it, like GraphGetSVGInput.GreaterThan.
+	GreaterThan *string
+}
+
+func (o *Options) validate() error {
+	var problems []pixela.FieldProblem
+	switch o.Mode {
+	case "", pixela.GraphModeShort, pixela.GraphModeBadge, pixela.GraphModeLine:
+	default:
+		problems = append(problems, pixela.FieldProblem{Field: "Mode", Message: "must be one of short, badge, line"})
+	}
+	switch o.Appearance {
+	case "", pixela.GraphAppearanceDark:
+	default:
+		problems = append(problems, pixela.FieldProblem{Field: "Appearance", Message: "must be one of dark"})
+	}
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{{"LessThan", o.LessThan}, {"GreaterThan", o.GreaterThan}} {
+		if f.value == nil {
+			continue
+		}
+		if _, err := strconv.ParseFloat(*f.value, 64); err != nil {
+			problems = append(problems, pixela.FieldProblem{Field: f.name, Message: "must be a number"})
+		}
+	}
+	if len(problems) > 0 {
+		return &pixela.ValidationError{Input: "Options", Problems: problems}
+	}
+	return nil
+}
+
+// shows reports whether a pixel of the quantity passes LessThan and GreaterThan.
+func (o *Options) shows(quantity float64) bool {
+	if o.LessThan != nil {
+		if v, _ := strconv.ParseFloat(*o.LessThan, 64); quantity >= v {
+			return false
+		}
+	}
+	if o.GreaterThan != nil {
+		if v, _ := strconv.ParseFloat(*o.GreaterThan, 64); quantity <= v {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	// levels is the number of colors of pixels with a positive quantity.
+	levels = 4
+	// defaultWeeks and shortWeeks are the numbers of weeks of the default and short modes.
+	defaultWeeks = 53
+	shortWeeks   = 13
+	// badgeCells is the number of cells of the badge mode, each of which is a week.
+	badgeCells = 7
+	// lineDays is the number of days of the line mode.
+	lineDays = 90
+)
+
+// theme is the colors of a graph.
+type theme struct {
+	background color.RGBA
+	text       color.RGBA
+	empty      color.RGBA
+	// levels are the colors from the smallest quantity to the largest one.
+	levels [levels]color.RGBA
+}
+
+// palettes are the colors of the levels of the graph colors in the light theme.
+var palettes = map[string][levels]color.RGBA{
+	pixela.GraphColorShibafu: {rgb(0x9be9a8), rgb(0x40c463), rgb(0x30a14e), rgb(0x216e39)},
+	pixela.GraphColorMomiji:  {rgb(0xffcdd2), rgb(0xef9a9a), rgb(0xe53935), rgb(0xb71c1c)},
+	pixela.GraphColorSora:    {rgb(0xbbdefb), rgb(0x64b5f6), rgb(0x1e88e5), rgb(0x0d47a1)},
+	pixela.GraphColorIchou:   {rgb(0xfff59d), rgb(0xfdd835), rgb(0xf9a825), rgb(0xf57f17)},
+	pixela.GraphColorAjisai:  {rgb(0xe1bee7), rgb(0xba68c8), rgb(0x8e24aa), rgb(0x4a148c)},
+	pixela.GraphColorKuro:    {rgb(0xbdbdbd), rgb(0x757575), rgb(0x424242), rgb(0x212121)},
+}
+
+func rgb(v uint32) color.RGBA {
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
+
+// newTheme returns the theme of the graph color and the appearance.
+// In the dark theme, the levels are reversed so that larger quantities are brighter.
+func newTheme(graphColor, appearance string) (*theme, error) {
+	palette, ok := palettes[graphColor]
+	if !ok {
+		return nil, fmt.Errorf("unknown graph color: %q", graphColor)
+	}
+	if appearance == pixela.GraphAppearanceDark {
+		slices.Reverse(palette[:])
+		return &theme{background: rgb(0x0d1117), text: rgb(0xc9d1d9), empty: rgb(0x161b22), levels: palette}, nil
+	}
+	return &theme{background: rgb(0xffffff), text: rgb(0x767676), empty: rgb(0xebedf0), levels: palette}, nil
+}
+
+// color returns the color of the level, where 0 is a day without a positive quantity.
+func (t *theme) color(level int) color.RGBA {
+	if level == 0 {
+		return t.empty
+	}
+	return t.levels[level-1]
+}
+
+// cell is a pixel of a graph.
+type cell struct {
+	column, row int
+	// date is the date of the pixel, or the first date of the week in the badge mode.
+	date time.Time
+	// quantity is the quantity of the pixel, or the total of the week in the badge mode.
+	// It is empty if there is no pixel.
+	quantity string
+	value    float64
+	level    int
+}
+
+// quantity is the quantity of a pixel.
+type quantity struct {
+	text  string
+	value float64
+}
+
+// label is a label of a column or a row.
+type label struct {
+	index int
+	text  string
+}
+
+// layout is the cells and the labels of a graph.
+type layout struct {
+	mode       string
+	appearance string
+	columns    int
+	rows       int
+	cells      []cell
+	months     []label
+	weekdays   []label
+	max        float64
+}
+
+func newLayout(def *pixela.GraphDefinition, pixels []pixela.PixelWithBody, opts *Options) (*layout, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	end := opts.Date
+	if end.IsZero() {
+		loc, err := def.Location()
+		if err != nil {
+			return nil, err
+		}
+		end = time.Now().In(loc)
+	}
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+
+	values := make(map[string]quantity, len(pixels))
+	for _, p := range pixels {
+		v, err := p.Float()
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity of %s: %w", p.Date, err)
+		}
+		if opts.shows(v) {
+			values[p.Date] = quantity{text: p.Quantity, value: v}
+		}
+	}
+
+	l := &layout{mode: opts.Mode, appearance: opts.Appearance}
+	switch opts.Mode {
+	case pixela.GraphModeBadge:
+		l.layoutBadge(end, values)
+	case pixela.GraphModeLine:
+		l.layoutLine(end, values)
+	case pixela.GraphModeShort:
+		l.layoutWeeks(end, shortWeeks, def.StartOnMonday, values)
+	default:
+		l.layoutWeeks(end, defaultWeeks, def.StartOnMonday, values)
+	}
+
+	for _, c := range l.cells {
+		l.max = max(l.max, c.value)
+	}
+	for i := range l.cells {
+		if v := l.cells[i].value; v > 0 {
+			l.cells[i].level = min(1+int(levels*v/l.max), levels)
+		}
+	}
+	return l, nil
+}
+
+// layoutWeeks lays out the days of weeks ending with end in columns of weeks.
+func (l *layout) layoutWeeks(end time.Time, weeks int, startOnMonday bool, values map[string]quantity) {
+	weekStart := time.Sunday
+	if startOnMonday {
+		weekStart = time.Monday
+	}
+	row := func(d time.Time) int {
+		return (int(d.Weekday()) - int(weekStart) + 7) % 7
+	}
+
+	first := end.AddDate(0, 0, -row(end)-(weeks-1)*7)
+	l.columns, l.rows = weeks, 7
+	for d, i := first, 0; !d.After(end); d, i = d.AddDate(0, 0, 1), i+1 {
+		l.cells = append(l.cells, newCell(i/7, row(d), d, values))
+		if d.Day() == 1 {
+			l.months = append(l.months, label{index: i / 7, text: d.Format("Jan")})
+		}
+		if i < 7 && (d.Weekday() == time.Monday || d.Weekday() == time.Wednesday || d.Weekday() == time.Friday) {
+			l.weekdays = append(l.weekdays, label{index: row(d), text: d.Format("Mon")})
+		}
+	}
+}
+
+// layoutBadge lays out the last 49 days ending with end in 7 cells of a week.
+func (l *layout) layoutBadge(end time.Time, values map[string]quantity) {
+	first := end.AddDate(0, 0, -badgeCells*7+1)
+	l.columns, l.rows = badgeCells, 1
+	for i := range badgeCells {
+		c := cell{column: i, date: first.AddDate(0, 0, i*7)}
+		found := false
+		for j := range 7 {
+			if q, ok := values[c.date.AddDate(0, 0, j).Format("20060102")]; ok {
+				c.value += q.value
+				found = true
+			}
+		}
+		if found {
+			c.quantity = strconv.FormatFloat(c.value, 'f', -1, 64)
+		}
+		l.cells = append(l.cells, c)
+	}
+}
+
+// layoutLine lays out the last 90 days ending with end in a row.
+func (l *layout) layoutLine(end time.Time, values map[string]quantity) {
+	first := end.AddDate(0, 0, -lineDays+1)
+	l.columns, l.rows = lineDays, 1
+	for i := range lineDays {
+		l.cells = append(l.cells, newCell(i, 0, first.AddDate(0, 0, i), values))
+	}
+}
+
+func newCell(column, row int, date time.Time, values map[string]quantity) cell {
+	c := cell{column: column, row: row, date: date}
+	if q, ok := values[date.Format("20060102")]; ok {
+		c.value = q.value
+		c.quantity = q.text
+	}
+	return c
+}
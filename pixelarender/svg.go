@@ -0,0 +1,141 @@
+package pixelarender
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+// Sizes of SVG graphs in pixels.
+const (
+	svgCellSize   = 10
+	svgCellStep   = 12
+	svgLeftMargin = 28
+	svgTopMargin  = 20
+	svgLineStep   = 4
+	svgLineHeight = 80
+	svgBadgeWidth = 6.5
+)
+
+// SVG writes the graph of the pixels to w in SVG.
+// Each pixel is a rect element with data-date and data-count attributes like the graphs of Pixela.
+func SVG(w io.Writer, def *pixela.GraphDefinition, pixels []pixela.PixelWithBody, opts *Options) error {
+	l, err := newLayout(def, pixels, opts)
+	if err != nil {
+		return err
+	}
+	t, err := newTheme(def.Color, l.appearance)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	switch l.mode {
+	case pixela.GraphModeBadge:
+		writeBadgeSVG(&b, def, l, t)
+	case pixela.GraphModeLine:
+		writeLineSVG(&b, def, l, t)
+	default:
+		writeHeatmapSVG(&b, def, l, t)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write svg: %w", err)
+	}
+	return nil
+}
+
+func writeHeatmapSVG(b *strings.Builder, def *pixela.GraphDefinition, l *layout, t *theme) {
+	width := svgLeftMargin + l.columns*svgCellStep
+	height := svgTopMargin + l.rows*svgCellStep
+	writeSVGStart(b, def, width, height, t)
+
+	fmt.Fprintf(b, `<g font-family="sans-serif" font-size="9" fill="%s">`, hex(t.text))
+	for _, m := range l.months {
+		fmt.Fprintf(b, `<text x="%d" y="%d">%s</text>`, svgLeftMargin+m.index*svgCellStep, svgTopMargin-6, m.text)
+	}
+	for _, d := range l.weekdays {
+		fmt.Fprintf(b, `<text x="0" y="%d">%s</text>`, svgTopMargin+d.index*svgCellStep+svgCellSize-1, d.text)
+	}
+	b.WriteString(`</g>`)
+
+	for _, c := range l.cells {
+		x, y := svgLeftMargin+c.column*svgCellStep, svgTopMargin+c.row*svgCellStep
+		writeRect(b, def, c, x, y, t)
+	}
+	b.WriteString(`</svg>`)
+}
+
+func writeBadgeSVG(b *strings.Builder, def *pixela.GraphDefinition, l *layout, t *theme) {
+	labelWidth := int(float64(utf8.RuneCountInString(def.Name))*svgBadgeWidth) + 10
+	width := labelWidth + l.columns*svgCellStep + 4
+	height := svgCellSize + 10
+	writeSVGStart(b, def, width, height, t)
+
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="#555555"/>`, labelWidth, height)
+	fmt.Fprintf(b, `<text x="5" y="14" font-family="sans-serif" font-size="11" fill="#ffffff">%s</text>`, html.EscapeString(def.Name))
+	for _, c := range l.cells {
+		writeRect(b, def, c, labelWidth+3+c.column*svgCellStep, 5, t)
+	}
+	b.WriteString(`</svg>`)
+}
+
+func writeLineSVG(b *strings.Builder, def *pixela.GraphDefinition, l *layout, t *theme) {
+	width := svgLeftMargin + l.columns*svgLineStep
+	height := svgTopMargin + svgLineHeight + 10
+	writeSVGStart(b, def, width, height, t)
+
+	y := func(c cell) float64 {
+		if l.max <= 0 {
+			return svgTopMargin + svgLineHeight
+		}
+		return svgTopMargin + svgLineHeight - max(c.value, 0)/l.max*svgLineHeight
+	}
+	fmt.Fprintf(b, `<text x="0" y="%d" font-family="sans-serif" font-size="9" fill="%s">%s</text>`,
+		svgTopMargin-6, hex(t.text), html.EscapeString(def.Unit))
+	fmt.Fprintf(b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s"/>`,
+		svgLeftMargin, svgTopMargin+svgLineHeight, width, svgTopMargin+svgLineHeight, hex(t.empty))
+
+	points := make([]string, len(l.cells))
+	for i, c := range l.cells {
+		points[i] = fmt.Sprintf("%d,%g", svgLeftMargin+c.column*svgLineStep, y(c))
+	}
+	fmt.Fprintf(b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(points, " "), hex(t.levels[levels-1]))
+	for _, c := range l.cells {
+		if c.quantity == "" {
+			continue
+		}
+		fmt.Fprintf(b, `<circle cx="%d" cy="%g" r="2" fill="%s" data-date="%s" data-count="%s"><title>%s</title></circle>`,
+			svgLeftMargin+c.column*svgLineStep, y(c), hex(t.levels[levels-1]),
+			c.date.Format("20060102"), html.EscapeString(c.quantity), title(def, c))
+	}
+	b.WriteString(`</svg>`)
+}
+
+func writeSVGStart(b *strings.Builder, def *pixela.GraphDefinition, width, height int, t *theme) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" data-graph-id="%s">`,
+		width, height, width, height, html.EscapeString(def.ID))
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" fill="%s"/>`, hex(t.background))
+}
+
+func writeRect(b *strings.Builder, def *pixela.GraphDefinition, c cell, x, y int, t *theme) {
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" rx="2" fill="%s" data-date="%s" data-count="%s"><title>%s</title></rect>`,
+		x, y, svgCellSize, svgCellSize, hex(t.color(c.level)), c.date.Format("20060102"), html.EscapeString(c.quantity), title(def, c))
+}
+
+func title(def *pixela.GraphDefinition, c cell) string {
+	quantity := c.quantity
+	if quantity == "" {
+		quantity = "0"
+	}
+	return html.EscapeString(fmt.Sprintf("%s %s %s", c.date.Format("2006-01-02"), quantity, def.Unit))
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,103 @@
+package pixelarender
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+var (
+	testDate   = time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+	testPixels = []pixela.PixelWithBody{
+		{Date: "20240101", Quantity: "4"},
+		{Date: "20240102", Quantity: "1"},
+	}
+)
+
+func newTestDefinition() *pixela.GraphDefinition {
+	return &pixela.GraphDefinition{ID: "graph-id", Name: "name", Unit: "times", Type: pixela.GraphTypeInt, Color: pixela.GraphColorShibafu}
+}
+
+func renderSVG(t *testing.T, def *pixela.GraphDefinition, opts *Options) string {
+	t.Helper()
+
+	var b strings.Builder
+	if err := SVG(&b, def, testPixels, opts); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	return b.String()
+}
+
+func TestSVG(t *testing.T) {
+	svg := renderSVG(t, newTestDefinition(), &Options{Date: testDate})
+
+	if got := strings.Count(svg, "<rect x="); got != 53*7 {
+		t.Errorf("got: %d\nwant: %d", got, 53*7)
+	}
+	expects := []string{
+		`<rect x="652" y="32" width="10" height="10" rx="2" fill="#216e39" data-date="20240101" data-count="4">` +
+			`<title>2024-01-01 4 times</title></rect>`,
+		`fill="#40c463" data-date="20240102" data-count="1"`,
+		`fill="#ebedf0" data-date="20240106" data-count=""`,
+		`<text x="652" y="14">Jan</text>`,
+		`<text x="0" y="41">Mon</text>`,
+	}
+	for _, expect := range expects {
+		if !strings.Contains(svg, expect) {
+			t.Errorf("got: %s\nwant: contains %s", svg, expect)
+		}
+	}
+	if strings.Contains(svg, `data-date="20240107"`) {
+		t.Errorf("got: %s\nwant: no pixel after the date", svg)
+	}
+}
+
+func TestSVG_StartOnMondayAndDark(t *testing.T) {
+	def := newTestDefinition()
+	def.StartOnMonday = true
+	svg := renderSVG(t, def, &Options{Date: testDate, Appearance: pixela.GraphAppearanceDark})
+
+	expects := []string{
+		`<rect width="100%" height="100%" fill="#0d1117"/>`,
+		`<rect x="652" y="20" width="10" height="10" rx="2" fill="#9be9a8" data-date="20240101"`,
+		`fill="#161b22" data-date="20240106"`,
+	}
+	for _, expect := range expects {
+		if !strings.Contains(svg, expect) {
+			t.Errorf("got: %s\nwant: contains %s", svg, expect)
+		}
+	}
+}
+
+func TestSVG_Modes(t *testing.T) {
+	params := []struct {
+		mode   string
+		rects  int
+		expect string
+	}{
+		{mode: pixela.GraphModeShort, rects: 13 * 7, expect: `data-date="20240101" data-count="4"`},
+		{mode: pixela.GraphModeBadge, rects: 7, expect: `data-date="20231231" data-count="5"`},
+		{mode: pixela.GraphModeLine, rects: 0, expect: `<circle cx="364" cy="20" r="2" fill="#216e39" data-date="20240101" data-count="4">`},
+	}
+	for _, p := range params {
+		svg := renderSVG(t, newTestDefinition(), &Options{Date: testDate, Mode: p.mode})
+
+		if got := strings.Count(svg, "<rect x="); got != p.rects {
+			t.Errorf("%s: got: %d\nwant: %d", p.mode, got, p.rects)
+		}
+		if !strings.Contains(svg, p.expect) {
+			t.Errorf("%s: got: %s\nwant: contains %s", p.mode, svg, p.expect)
+		}
+	}
+}
+
+func TestSVG_InvalidOptions(t *testing.T) {
+	err := SVG(&strings.Builder{}, newTestDefinition(), testPixels, &Options{Mode: pixela.GraphModeSimple})
+
+	if errors.Is(err, pixela.ErrInvalidInput) == false {
+		t.Errorf("got: %v\nwant: %v", err, pixela.ErrInvalidInput)
+	}
+}
@@ -0,0 +1,278 @@
+// Package pixelastats computes statistics of pixela graphs locally from their pixels,
+// such as streaks, weekly, monthly and yearly aggregates, moving averages, percentiles,
+// day-of-week distributions and trends.
+//
+//	var pixels []pixela.PixelWithBody
+//	for p, err := range client.Graph().AllPixels(ctx, &pixela.GraphAllPixelsInput{ID: pixela.String("graph-id"), From: from, To: to}) {
+//		if err != nil {
+//			return err
+//		}
+//		pixels = append(pixels, p)
+//	}
+//	series, err := pixelastats.New(def.Type, pixels)
+//
+// Quantities are computed as *big.Rat, so that the results of float graphs are exact
+// unlike float64, and the results of int graphs are not truncated unlike pixela.Stats.
+package pixelastats
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+// ErrNoPixels is returned when a statistic needs more pixels than the series has.
+var ErrNoPixels = errors.New("not enough pixels")
+
+// A Series is the pixels of a graph in date order.
+type Series struct {
+	days []day
+}
+
+type day struct {
+	date  time.Time
+	value *big.Rat
+}
+
+// New returns the series of the pixels of a graph of graphType (pixela.GraphTypeInt or pixela.GraphTypeFloat).
+// It returns an error if a pixel has an invalid date or quantity, or if two pixels have the same date.
+func New(graphType string, pixels []pixela.PixelWithBody) (*Series, error) {
+	days := make([]day, 0, len(pixels))
+	for _, p := range pixels {
+		date, err := p.Time()
+		if err != nil {
+			return nil, fmt.Errorf("invalid pixel date: %w", err)
+		}
+		if err := pixela.ValidateQuantity(graphType, p.Quantity); err != nil {
+			return nil, fmt.Errorf("invalid quantity of %s: %w", p.Date, err)
+		}
+		value, _ := new(big.Rat).SetString(p.Quantity)
+		days = append(days, day{date: date, value: value})
+	}
+
+	slices.SortFunc(days, func(a, b day) int { return a.date.Compare(b.date) })
+	for i := 1; i < len(days); i++ {
+		if days[i].date.Equal(days[i-1].date) {
+			return nil, fmt.Errorf("duplicate pixel date: %s", days[i].date.Format("20060102"))
+		}
+	}
+	return &Series{days: days}, nil
+}
+
+// Len returns the number of pixels.
+func (s *Series) Len() int {
+	return len(s.days)
+}
+
+// A Streak is consecutive days whose pixels have a positive quantity.
+type Streak struct {
+	// Start is the first day. It is zero if there is no streak.
+	Start time.Time
+	// End is the last day.
+	End time.Time
+	// Days is the number of days.
+	Days int
+}
+
+// Streaks are the current and the longest streaks.
+type Streaks struct {
+	// Current is the streak that ends on today or yesterday, since today may not be recorded yet.
+	Current Streak
+	// Longest is the longest streak. It is the earliest one if there are several.
+	Longest Streak
+}
+
+// Streaks returns the streaks as of the date of today.
+func (s *Series) Streaks(today time.Time) Streaks {
+	var (
+		streaks Streaks
+		run     Streak
+	)
+	for _, d := range s.days {
+		if d.value.Sign() <= 0 {
+			continue
+		}
+		if run.Days > 0 && d.date.Equal(run.End.AddDate(0, 0, 1)) {
+			run.End = d.date
+			run.Days++
+		} else {
+			run = Streak{Start: d.date, End: d.date, Days: 1}
+		}
+		if run.Days > streaks.Longest.Days {
+			streaks.Longest = run
+		}
+	}
+
+	today = date(today)
+	if run.Days > 0 && (run.End.Equal(today) || run.End.Equal(today.AddDate(0, 0, -1))) {
+		streaks.Current = run
+	}
+	return streaks
+}
+
+// An Aggregate is the statistics of the pixels of a period.
+type Aggregate struct {
+	// Start is the first day of the period. It is zero for the aggregates by day of the week.
+	Start time.Time
+	// Count is the number of pixels. The other fields are nil if it is 0.
+	Count   int
+	Total   *big.Rat
+	Min     *big.Rat
+	Max     *big.Rat
+	Average *big.Rat
+}
+
+func (a *Aggregate) add(value *big.Rat) {
+	if a.Count == 0 {
+		a.Total, a.Min, a.Max = new(big.Rat), new(big.Rat).Set(value), new(big.Rat).Set(value)
+	}
+	a.Count++
+	a.Total.Add(a.Total, value)
+	if value.Cmp(a.Min) < 0 {
+		a.Min.Set(value)
+	}
+	if value.Cmp(a.Max) > 0 {
+		a.Max.Set(value)
+	}
+	a.Average = new(big.Rat).Quo(a.Total, new(big.Rat).SetInt64(int64(a.Count)))
+}
+
+// Weekly returns the aggregates of the weeks that have pixels, in date order.
+// A week starts on Sunday, or on Monday if startOnMonday is true.
+func (s *Series) Weekly(startOnMonday bool) []Aggregate {
+	weekStart := time.Sunday
+	if startOnMonday {
+		weekStart = time.Monday
+	}
+	return s.aggregate(func(d time.Time) time.Time {
+		return d.AddDate(0, 0, -(int(d.Weekday())-int(weekStart)+7)%7)
+	})
+}
+
+// Monthly returns the aggregates of the months that have pixels, in date order.
+func (s *Series) Monthly() []Aggregate {
+	return s.aggregate(func(d time.Time) time.Time {
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	})
+}
+
+// Yearly returns the aggregates of the years that have pixels, in date order.
+func (s *Series) Yearly() []Aggregate {
+	return s.aggregate(func(d time.Time) time.Time {
+		return time.Date(d.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	})
+}
+
+// aggregate aggregates the pixels by the periods that start on the date returned by start.
+func (s *Series) aggregate(start func(time.Time) time.Time) []Aggregate {
+	var aggregates []Aggregate
+	for _, d := range s.days {
+		st := start(d.date)
+		if len(aggregates) == 0 || !aggregates[len(aggregates)-1].Start.Equal(st) {
+			aggregates = append(aggregates, Aggregate{Start: st})
+		}
+		aggregates[len(aggregates)-1].add(d.value)
+	}
+	return aggregates
+}
+
+// Weekdays returns the aggregates by day of the week, indexed by time.Weekday.
+func (s *Series) Weekdays() [7]Aggregate {
+	var aggregates [7]Aggregate
+	for _, d := range s.days {
+		aggregates[d.date.Weekday()].add(d.value)
+	}
+	return aggregates
+}
+
+// A Point is a value of a day.
+type Point struct {
+	Date  time.Time
+	Value *big.Rat
+}
+
+// MovingAverage returns the averages of the quantities of window consecutive days
+// ending on each day from the window-th day of the series to the last day.
+// Days without a pixel count as 0. It returns nil if the series has fewer days than window.
+func (s *Series) MovingAverage(window int) []Point {
+	if window <= 0 || len(s.days) == 0 {
+		return nil
+	}
+
+	first, last := s.days[0].date, s.days[len(s.days)-1].date
+	n := int(last.Sub(first).Hours()/24) + 1
+	values := make([]*big.Rat, n)
+	for i := range values {
+		values[i] = new(big.Rat)
+	}
+	for _, d := range s.days {
+		values[int(d.date.Sub(first).Hours()/24)] = d.value
+	}
+
+	var points []Point
+	sum := new(big.Rat)
+	for i, v := range values {
+		sum.Add(sum, v)
+		if i >= window {
+			sum.Sub(sum, values[i-window])
+		}
+		if i >= window-1 {
+			average := new(big.Rat).Quo(sum, new(big.Rat).SetInt64(int64(window)))
+			points = append(points, Point{Date: first.AddDate(0, 0, i), Value: average})
+		}
+	}
+	return points
+}
+
+// Percentile returns the p-th percentile (0 to 100) of the quantities by the nearest-rank method.
+func (s *Series) Percentile(p float64) (*big.Rat, error) {
+	if p < 0 || p > 100 {
+		return nil, fmt.Errorf("percentile must be from 0 to 100: %g", p)
+	}
+	if len(s.days) == 0 {
+		return nil, ErrNoPixels
+	}
+
+	values := make([]*big.Rat, len(s.days))
+	for i, d := range s.days {
+		values[i] = d.value
+	}
+	slices.SortFunc(values, (*big.Rat).Cmp)
+	rank := int(p / 100 * float64(len(values)))
+	if float64(rank) < p/100*float64(len(values)) {
+		rank++
+	}
+	return new(big.Rat).Set(values[max(rank, 1)-1]), nil
+}
+
+// Trend returns the slope of the least squares line of the quantities, in quantity per day.
+// It returns ErrNoPixels if the series has fewer than 2 pixels.
+func (s *Series) Trend() (*big.Rat, error) {
+	if len(s.days) < 2 {
+		return nil, ErrNoPixels
+	}
+
+	n := new(big.Rat).SetInt64(int64(len(s.days)))
+	sumX, sumY, sumXY, sumXX := new(big.Rat), new(big.Rat), new(big.Rat), new(big.Rat)
+	first := s.days[0].date
+	for _, d := range s.days {
+		x := new(big.Rat).SetInt64(int64(d.date.Sub(first).Hours() / 24))
+		sumX.Add(sumX, x)
+		sumY.Add(sumY, d.value)
+		sumXY.Add(sumXY, new(big.Rat).Mul(x, d.value))
+		sumXX.Add(sumXX, new(big.Rat).Mul(x, x))
+	}
+
+	// slope = (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	numerator := new(big.Rat).Sub(new(big.Rat).Mul(n, sumXY), new(big.Rat).Mul(sumX, sumY))
+	denominator := new(big.Rat).Sub(new(big.Rat).Mul(n, sumXX), new(big.Rat).Mul(sumX, sumX))
+	return numerator.Quo(numerator, denominator), nil
+}
+
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
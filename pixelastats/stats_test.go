@@ -0,0 +1,234 @@
+package pixelastats
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+)
+
+var testPixels = []pixela.PixelWithBody{
+	{Date: "20240105", Quantity: "2"},
+	{Date: "20231230", Quantity: "0.1"},
+	{Date: "20231231", Quantity: "0.2"},
+	{Date: "20240101", Quantity: "0.3"},
+	{Date: "20240102", Quantity: "0"},
+	{Date: "20240104", Quantity: "1.5"},
+}
+
+func newTestSeries(t *testing.T) *Series {
+	t.Helper()
+
+	s, err := New(pixela.GraphTypeFloat, testPixels)
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	return s
+}
+
+func newDate(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+// equal reports whether got is the rational number of want such as "0.15" or "1/6".
+func equal(got *big.Rat, want string) bool {
+	w, ok := new(big.Rat).SetString(want)
+	return ok && got != nil && got.Cmp(w) == 0
+}
+
+func TestNew_Errors(t *testing.T) {
+	params := []struct {
+		name      string
+		graphType string
+		pixels    []pixela.PixelWithBody
+	}{
+		{name: "decimal of int graph", graphType: pixela.GraphTypeInt, pixels: []pixela.PixelWithBody{{Date: "20240101", Quantity: "1.5"}}},
+		{name: "invalid date", graphType: pixela.GraphTypeInt, pixels: []pixela.PixelWithBody{{Date: "2024-01-01", Quantity: "1"}}},
+		{name: "duplicate date", graphType: pixela.GraphTypeInt, pixels: []pixela.PixelWithBody{{Date: "20240101", Quantity: "1"}, {Date: "20240101", Quantity: "2"}}},
+	}
+	for _, p := range params {
+		if _, err := New(p.graphType, p.pixels); err == nil {
+			t.Errorf("%s: got: nil\nwant: error", p.name)
+		}
+	}
+}
+
+func TestSeries_Streaks(t *testing.T) {
+	s := newTestSeries(t)
+
+	params := []struct {
+		today   time.Time
+		current Streak
+	}{
+		{today: newDate(2024, 1, 5), current: Streak{Start: newDate(2024, 1, 4), End: newDate(2024, 1, 5), Days: 2}},
+		{today: time.Date(2024, 1, 6, 23, 59, 0, 0, time.UTC), current: Streak{Start: newDate(2024, 1, 4), End: newDate(2024, 1, 5), Days: 2}},
+		{today: newDate(2024, 1, 7), current: Streak{}},
+	}
+	for _, p := range params {
+		got := s.Streaks(p.today)
+
+		want := Streaks{Current: p.current, Longest: Streak{Start: newDate(2023, 12, 30), End: newDate(2024, 1, 1), Days: 3}}
+		if got != want {
+			t.Errorf("%v: got: %+v\nwant: %+v", p.today, got, want)
+		}
+	}
+}
+
+func TestSeries_Aggregates(t *testing.T) {
+	s := newTestSeries(t)
+
+	type expect struct {
+		start                    time.Time
+		count                    int
+		total, min, max, average string
+	}
+	params := []struct {
+		name    string
+		got     []Aggregate
+		expects []expect
+	}{
+		{
+			name: "weekly from Sunday",
+			got:  s.Weekly(false),
+			expects: []expect{
+				{start: newDate(2023, 12, 24), count: 1, total: "0.1", min: "0.1", max: "0.1", average: "0.1"},
+				{start: newDate(2023, 12, 31), count: 5, total: "4", min: "0", max: "2", average: "0.8"},
+			},
+		},
+		{
+			name: "weekly from Monday",
+			got:  s.Weekly(true),
+			expects: []expect{
+				{start: newDate(2023, 12, 25), count: 2, total: "0.3", min: "0.1", max: "0.2", average: "0.15"},
+				{start: newDate(2024, 1, 1), count: 4, total: "3.8", min: "0", max: "2", average: "0.95"},
+			},
+		},
+		{
+			name: "monthly",
+			got:  s.Monthly(),
+			expects: []expect{
+				{start: newDate(2023, 12, 1), count: 2, total: "0.3", min: "0.1", max: "0.2", average: "0.15"},
+				{start: newDate(2024, 1, 1), count: 4, total: "3.8", min: "0", max: "2", average: "0.95"},
+			},
+		},
+		{
+			name: "yearly",
+			got:  s.Yearly(),
+			expects: []expect{
+				{start: newDate(2023, 1, 1), count: 2, total: "0.3", min: "0.1", max: "0.2", average: "0.15"},
+				{start: newDate(2024, 1, 1), count: 4, total: "3.8", min: "0", max: "2", average: "0.95"},
+			},
+		},
+	}
+	for _, p := range params {
+		if len(p.got) != len(p.expects) {
+			t.Fatalf("%s: got: %d aggregates\nwant: %d", p.name, len(p.got), len(p.expects))
+		}
+		for i, e := range p.expects {
+			a := p.got[i]
+			if a.Start.Equal(e.start) == false || a.Count != e.count ||
+				!equal(a.Total, e.total) || !equal(a.Min, e.min) || !equal(a.Max, e.max) || !equal(a.Average, e.average) {
+				t.Errorf("%s: got: %v %d %v %v %v %v\nwant: %+v", p.name, a.Start, a.Count, a.Total, a.Min, a.Max, a.Average, e)
+			}
+		}
+	}
+}
+
+func TestSeries_Weekdays(t *testing.T) {
+	got := newTestSeries(t).Weekdays()
+
+	totals := map[time.Weekday]string{
+		time.Sunday:   "0.2",
+		time.Monday:   "0.3",
+		time.Tuesday:  "0",
+		time.Thursday: "1.5",
+		time.Friday:   "2",
+		time.Saturday: "0.1",
+	}
+	for d, total := range totals {
+		if got[d].Count != 1 || !equal(got[d].Total, total) {
+			t.Errorf("%s: got: %d %v\nwant: 1 %s", d, got[d].Count, got[d].Total, total)
+		}
+	}
+	if got[time.Wednesday].Count != 0 || got[time.Wednesday].Total != nil {
+		t.Errorf("got: %+v\nwant: empty", got[time.Wednesday])
+	}
+}
+
+func TestSeries_MovingAverage(t *testing.T) {
+	got := newTestSeries(t).MovingAverage(3)
+
+	expects := []struct {
+		date  time.Time
+		value string
+	}{
+		{date: newDate(2024, 1, 1), value: "0.2"},
+		{date: newDate(2024, 1, 2), value: "1/6"},
+		{date: newDate(2024, 1, 3), value: "0.1"},
+		{date: newDate(2024, 1, 4), value: "0.5"},
+		{date: newDate(2024, 1, 5), value: "7/6"},
+	}
+	if len(got) != len(expects) {
+		t.Fatalf("got: %v\nwant: %v", got, expects)
+	}
+	for i, e := range expects {
+		if got[i].Date.Equal(e.date) == false || !equal(got[i].Value, e.value) {
+			t.Errorf("got: %v %v\nwant: %v %s", got[i].Date, got[i].Value, e.date, e.value)
+		}
+	}
+
+	if got := newTestSeries(t).MovingAverage(8); got != nil {
+		t.Errorf("got: %v\nwant: nil", got)
+	}
+}
+
+func TestSeries_Percentile(t *testing.T) {
+	s := newTestSeries(t)
+
+	params := []struct {
+		p      float64
+		expect string
+	}{
+		{p: 0, expect: "0"},
+		{p: 50, expect: "0.2"},
+		{p: 90, expect: "2"},
+		{p: 100, expect: "2"},
+	}
+	for _, p := range params {
+		got, err := s.Percentile(p.p)
+		if err != nil || !equal(got, p.expect) {
+			t.Errorf("%g: got: %v, %v\nwant: %s, nil", p.p, got, err, p.expect)
+		}
+	}
+
+	if _, err := s.Percentile(101); err == nil {
+		t.Errorf("got: nil\nwant: error")
+	}
+	empty, _ := New(pixela.GraphTypeInt, nil)
+	if _, err := empty.Percentile(50); errors.Is(err, ErrNoPixels) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrNoPixels)
+	}
+}
+
+func TestSeries_Trend(t *testing.T) {
+	s, err := New(pixela.GraphTypeInt, []pixela.PixelWithBody{
+		{Date: "20240101", Quantity: "1"},
+		{Date: "20240102", Quantity: "3"},
+		{Date: "20240104", Quantity: "7"},
+	})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	got, err := s.Trend()
+	if err != nil || !equal(got, "2") {
+		t.Errorf("got: %v, %v\nwant: 2, nil", got, err)
+	}
+
+	one, _ := New(pixela.GraphTypeInt, []pixela.PixelWithBody{{Date: "20240101", Quantity: "1"}})
+	if _, err := one.Trend(); errors.Is(err, ErrNoPixels) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrNoPixels)
+	}
+}
@@ -0,0 +1,123 @@
+package pixelatest
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var channelIDPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{1,16}$`)
+
+type channel struct {
+	ID     string      `json:"id"`
+	Name   string      `json:"name"`
+	Type   string      `json:"type"`
+	Detail slackDetail `json:"detail"`
+}
+
+type slackDetail struct {
+	URL         string `json:"url"`
+	UserName    string `json:"userName"`
+	ChannelName string `json:"channelName"`
+}
+
+// validate returns the name of the first invalid field, or "" if the channel is valid.
+func (c *channel) validate() string {
+	switch {
+	case c.Name == "":
+		return "name"
+	case c.Type != "slack":
+		return "type"
+	case !strings.HasPrefix(c.Detail.URL, "https://"):
+		return "detail.url"
+	case c.Detail.UserName == "":
+		return "detail.userName"
+	case c.Detail.ChannelName == "":
+		return "detail.channelName"
+	}
+	return ""
+}
+
+func (s *Server) createChannel(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	var c channel
+	if !decodeBody(w, r, &c) {
+		return
+	}
+	if !channelIDPattern.MatchString(c.ID) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check id.")
+		return
+	}
+	if field := c.validate(); field != "" {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check "+field+".")
+		return
+	}
+	if _, ok := u.channels[c.ID]; ok {
+		writeFailure(w, http.StatusConflict, "This channel already exist.")
+		return
+	}
+
+	u.channels[c.ID] = &c
+	writeSuccess(w)
+}
+
+func (s *Server) getChannels(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	channels := []*channel{}
+	for _, c := range u.channels {
+		channels = append(channels, c)
+	}
+	slices.SortFunc(channels, func(a, b *channel) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	writeJSON(w, http.StatusOK, map[string]any{"channels": channels})
+}
+
+func (s *Server) updateChannel(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	id := r.PathValue("channel")
+	if _, ok := u.channels[id]; !ok {
+		writeFailure(w, http.StatusNotFound, "Specified channel not found.")
+		return
+	}
+	c := channel{ID: id}
+	if !decodeBody(w, r, &c) {
+		return
+	}
+	c.ID = id
+	if field := c.validate(); field != "" {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check "+field+".")
+		return
+	}
+
+	u.channels[id] = &c
+	writeSuccess(w)
+}
+
+func (s *Server) deleteChannel(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	id := r.PathValue("channel")
+	if _, ok := u.channels[id]; !ok {
+		writeFailure(w, http.StatusNotFound, "Specified channel not found.")
+		return
+	}
+	delete(u.channels, id)
+	writeSuccess(w)
+}
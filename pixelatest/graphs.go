@@ -0,0 +1,382 @@
+package pixelatest
+
+import (
+	"fmt"
+	"html"
+	"math/big"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	graphTypeInt   = "int"
+	graphTypeFloat = "float"
+)
+
+var (
+	graphIDPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{1,16}$`)
+	graphColors    = []string{"shibafu", "momiji", "sora", "ichou", "ajisai", "kuro"}
+	selfSufficient = []string{"increment", "decrement", "none"}
+)
+
+type graph struct {
+	definition     graphDefinition
+	pixels         map[string]*pixel
+	notifications  map[string]*notification
+	stopwatchStart *time.Time
+}
+
+type graphDefinition struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	Unit                string   `json:"unit"`
+	Type                string   `json:"type"`
+	Color               string   `json:"color"`
+	TimeZone            string   `json:"timezone"`
+	PurgeCacheURLs      []string `json:"purgeCacheURLs,omitempty"`
+	SelfSufficient      string   `json:"selfSufficient"`
+	IsSecret            bool     `json:"isSecret"`
+	PublishOptionalData bool     `json:"publishOptionalData"`
+	Description         string   `json:"description,omitempty"`
+	StartOnMonday       bool     `json:"startOnMonday,omitempty"`
+}
+
+func validTimezone(name string) bool {
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// location returns the graph's timezone, falling back to UTC.
+func (g *graph) location() *time.Location {
+	loc, err := time.LoadLocation(g.definition.TimeZone)
+	if err != nil || g.definition.TimeZone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+func (g *graph) today(now time.Time) string {
+	return now.In(g.location()).Format(dateLayout)
+}
+
+// findGraph returns the graph named in the request path.
+// It writes an error response and returns nil if the user or the graph does not exist.
+func (s *Server) findGraph(w http.ResponseWriter, r *http.Request) *graph {
+	u, ok := s.users[r.PathValue("user")]
+	if !ok {
+		writeFailure(w, http.StatusNotFound, "Specified user not found.")
+		return nil
+	}
+	g, ok := u.graphs[r.PathValue("graph")]
+	if !ok {
+		writeFailure(w, http.StatusNotFound, "Specified graph not found.")
+		return nil
+	}
+	if g.definition.IsSecret && u.token != r.Header.Get(userToken) {
+		writeFailure(w, http.StatusNotFound, "Specified graph not found.")
+		return nil
+	}
+	return g
+}
+
+// authenticatedGraph authenticates the request and returns the graph named in the request path.
+func (s *Server) authenticatedGraph(w http.ResponseWriter, r *http.Request) *graph {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return nil
+	}
+	g, ok := u.graphs[r.PathValue("graph")]
+	if !ok {
+		writeFailure(w, http.StatusNotFound, "Specified graph not found.")
+		return nil
+	}
+	return g
+}
+
+func (s *Server) createGraph(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	var def graphDefinition
+	if !decodeBody(w, r, &def) {
+		return
+	}
+	if def.SelfSufficient == "" {
+		def.SelfSufficient = "none"
+	}
+	switch {
+	case !graphIDPattern.MatchString(def.ID):
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check id.")
+		return
+	case def.Name == "" || def.Unit == "":
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check name and unit.")
+		return
+	case def.Type != graphTypeInt && def.Type != graphTypeFloat:
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check type.")
+		return
+	case !slices.Contains(graphColors, def.Color):
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check color.")
+		return
+	case !slices.Contains(selfSufficient, def.SelfSufficient):
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check selfSufficient.")
+		return
+	case def.TimeZone != "" && !validTimezone(def.TimeZone):
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check timezone.")
+		return
+	}
+	if _, ok := u.graphs[def.ID]; ok {
+		writeFailure(w, http.StatusConflict, "This graph already exist.")
+		return
+	}
+
+	u.graphs[def.ID] = &graph{definition: def, pixels: map[string]*pixel{}, notifications: map[string]*notification{}}
+	writeSuccess(w)
+}
+
+func (s *Server) getGraphs(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	graphs := make([]graphDefinition, 0, len(u.graphs))
+	for _, g := range u.graphs {
+		graphs = append(graphs, g.definition)
+	}
+	slices.SortFunc(graphs, func(a, b graphDefinition) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	writeJSON(w, http.StatusOK, map[string]any{"graphs": graphs})
+}
+
+func (s *Server) getGraph(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, g.definition)
+}
+
+func (s *Server) updateGraph(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	var input struct {
+		Name                *string  `json:"name"`
+		Unit                *string  `json:"unit"`
+		Color               *string  `json:"color"`
+		TimeZone            *string  `json:"timezone"`
+		Description         *string  `json:"description"`
+		PurgeCacheURLs      []string `json:"purgeCacheURLs"`
+		SelfSufficient      *string  `json:"selfSufficient"`
+		IsSecret            *bool    `json:"isSecret"`
+		PublishOptionalData *bool    `json:"publishOptionalData"`
+		StartOnMonday       *bool    `json:"startOnMonday"`
+	}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+	switch {
+	case input.Color != nil && !slices.Contains(graphColors, *input.Color):
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check color.")
+		return
+	case input.SelfSufficient != nil && !slices.Contains(selfSufficient, *input.SelfSufficient):
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check selfSufficient.")
+		return
+	case input.TimeZone != nil && !validTimezone(*input.TimeZone):
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check timezone.")
+		return
+	}
+
+	def := &g.definition
+	setIfNotNil(&def.Name, input.Name)
+	setIfNotNil(&def.Unit, input.Unit)
+	setIfNotNil(&def.Color, input.Color)
+	setIfNotNil(&def.TimeZone, input.TimeZone)
+	setIfNotNil(&def.Description, input.Description)
+	setIfNotNil(&def.SelfSufficient, input.SelfSufficient)
+	setIfNotNil(&def.IsSecret, input.IsSecret)
+	setIfNotNil(&def.PublishOptionalData, input.PublishOptionalData)
+	setIfNotNil(&def.StartOnMonday, input.StartOnMonday)
+	if input.PurgeCacheURLs != nil {
+		def.PurgeCacheURLs = input.PurgeCacheURLs
+	}
+	writeSuccess(w)
+}
+
+func setIfNotNil[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+func (s *Server) deleteGraph(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+	id := r.PathValue("graph")
+	if _, ok := u.graphs[id]; !ok {
+		writeFailure(w, http.StatusNotFound, "Specified graph not found.")
+		return
+	}
+
+	for hash, wh := range s.webhooks {
+		if wh.userName == u.name && wh.GraphID == id {
+			delete(s.webhooks, hash)
+		}
+	}
+	delete(u.graphs, id)
+	writeSuccess(w)
+}
+
+// getGraphSVG serves both "/graphs/<id>" (SVG) and "/graphs/<id>.html" (HTML).
+func (s *Server) getGraphSVG(w http.ResponseWriter, r *http.Request) {
+	if id, ok := strings.CutSuffix(r.PathValue("graph"), ".html"); ok {
+		r.SetPathValue("graph", id)
+		s.getGraphHTML(w, r)
+		return
+	}
+
+	g := s.findGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" data-graph-id="%s" data-mode="%s" data-appearance="%s">`,
+		html.EscapeString(g.definition.ID),
+		html.EscapeString(r.URL.Query().Get("mode")),
+		html.EscapeString(r.URL.Query().Get("appearance")))
+	for _, p := range g.sortedPixels("", "") {
+		fmt.Fprintf(&b, `<rect data-date="%s" data-count="%s"/>`, p.Date, html.EscapeString(p.Quantity))
+	}
+	b.WriteString(`</svg>`)
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	fmt.Fprint(w, b.String())
+}
+
+func (s *Server) getGraphHTML(w http.ResponseWriter, r *http.Request) {
+	g := s.findGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<!DOCTYPE html><html><head><title>%s</title></head><body><h1>%s</h1></body></html>",
+		html.EscapeString(g.definition.Name), html.EscapeString(g.definition.Name))
+}
+
+func (s *Server) getGraphStats(w http.ResponseWriter, r *http.Request) {
+	g := s.findGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	pixels := g.sortedPixels("", "")
+	stats := map[string]any{
+		"totalPixelsCount":  len(pixels),
+		"maxQuantity":       0,
+		"maxDate":           "",
+		"minQuantity":       0,
+		"minDate":           "",
+		"totalQuantity":     0,
+		"avgQuantity":       0,
+		"todaysQuantity":    0,
+		"yesterdayQuantity": 0,
+	}
+	if len(pixels) == 0 {
+		writeJSON(w, http.StatusOK, stats)
+		return
+	}
+
+	var maxP, minP *pixel
+	total := new(big.Rat)
+	for _, p := range pixels {
+		q, _ := new(big.Rat).SetString(p.Quantity)
+		total.Add(total, q)
+		if maxP == nil || q.Cmp(ratOf(maxP.Quantity)) > 0 {
+			maxP = p
+		}
+		if minP == nil || q.Cmp(ratOf(minP.Quantity)) < 0 {
+			minP = p
+		}
+	}
+	avg, _ := new(big.Rat).Quo(total, big.NewRat(int64(len(pixels)), 1)).Float64()
+
+	now := s.now().In(g.location())
+	quantityOf := func(date string) any {
+		if p, ok := g.pixels[date]; ok {
+			return jsonNumber(p.Quantity)
+		}
+		return 0
+	}
+
+	stats["maxQuantity"] = jsonNumber(maxP.Quantity)
+	stats["maxDate"] = statsDate(maxP.Date)
+	stats["minQuantity"] = jsonNumber(minP.Quantity)
+	stats["minDate"] = statsDate(minP.Date)
+	stats["totalQuantity"] = jsonNumber(formatQuantity(g.definition.Type, total))
+	stats["avgQuantity"] = jsonNumber(strconv.FormatFloat(avg, 'f', -1, 64))
+	stats["todaysQuantity"] = quantityOf(now.Format(dateLayout))
+	stats["yesterdayQuantity"] = quantityOf(now.AddDate(0, 0, -1).Format(dateLayout))
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// jsonNumber is a quantity string that is encoded as a JSON number.
+type jsonNumber string
+
+func (n jsonNumber) MarshalJSON() ([]byte, error) {
+	return []byte(n), nil
+}
+
+func ratOf(quantity string) *big.Rat {
+	r, _ := new(big.Rat).SetString(quantity)
+	return r
+}
+
+func statsDate(date string) string {
+	t, _ := time.Parse(dateLayout, date)
+	return t.Format("2006-01-02")
+}
+
+func (s *Server) analyzeGraph(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	analysis := fmt.Sprintf("The graph %q has %d pixels.", g.definition.Name, len(g.pixels))
+	writeJSON(w, http.StatusOK, map[string]any{"analysis": analysis})
+}
+
+// stopwatch starts the measurement on the first call and,
+// on the second call, adds the elapsed minutes to the pixel of the day.
+func (s *Server) stopwatch(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+	g.toggleStopwatch(s.now())
+	writeSuccess(w)
+}
+
+func (g *graph) toggleStopwatch(now time.Time) {
+	if g.stopwatchStart == nil {
+		g.stopwatchStart = &now
+		return
+	}
+
+	minutes := int64(now.Sub(*g.stopwatchStart) / time.Minute)
+	g.stopwatchStart = nil
+	g.addToPixel(g.today(now), strconv.FormatInt(minutes, 10))
+}
@@ -0,0 +1,155 @@
+package pixelatest
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	notificationIDPattern  = regexp.MustCompile(`^[a-z][a-z0-9-]{1,16}$`)
+	notificationConditions = []string{">", "=", "<", "multipleOf"}
+)
+
+type notification struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Target    string `json:"target"`
+	Condition string `json:"condition"`
+	Threshold string `json:"threshold"`
+	RemindBy  string `json:"remindBy,omitempty"`
+	ChannelID string `json:"channelID"`
+}
+
+// validate returns the name of the first invalid field, or "" if the notification is valid.
+func (n *notification) validate(u *user, g *graph) string {
+	switch {
+	case n.Name == "":
+		return "name"
+	case n.Target != "quantity":
+		return "target"
+	case !slices.Contains(notificationConditions, n.Condition):
+		return "condition"
+	case !g.validQuantity(n.Threshold):
+		return "threshold"
+	case n.RemindBy != "" && !validHour(n.RemindBy):
+		return "remindBy"
+	}
+	if _, ok := u.channels[n.ChannelID]; !ok {
+		return "channelID"
+	}
+	return ""
+}
+
+func validHour(s string) bool {
+	h, err := strconv.Atoi(s)
+	return err == nil && 0 <= h && h <= 23
+}
+
+func (s *Server) createNotification(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+	u := s.users[r.PathValue("user")]
+
+	var n notification
+	if !decodeBody(w, r, &n) {
+		return
+	}
+	if !notificationIDPattern.MatchString(n.ID) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check id.")
+		return
+	}
+	if field := n.validate(u, g); field != "" {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check "+field+".")
+		return
+	}
+	if _, ok := g.notifications[n.ID]; ok {
+		writeFailure(w, http.StatusConflict, "This notification already exist.")
+		return
+	}
+
+	g.notifications[n.ID] = &n
+	writeSuccess(w)
+}
+
+func (s *Server) getNotifications(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	notifications := []*notification{}
+	for _, n := range g.notifications {
+		notifications = append(notifications, n)
+	}
+	slices.SortFunc(notifications, func(a, b *notification) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	writeJSON(w, http.StatusOK, map[string]any{"notifications": notifications})
+}
+
+// updateNotification updates only the fields specified in the request body.
+func (s *Server) updateNotification(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+	u := s.users[r.PathValue("user")]
+
+	id := r.PathValue("notification")
+	current, ok := g.notifications[id]
+	if !ok {
+		writeFailure(w, http.StatusNotFound, "Specified notification not found.")
+		return
+	}
+	n := *current
+	if !decodeBody(w, r, &n) {
+		return
+	}
+	n.ID = id
+	if field := n.validate(u, g); field != "" {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check "+field+".")
+		return
+	}
+
+	g.notifications[id] = &n
+	writeSuccess(w)
+}
+
+func (s *Server) deleteNotification(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	id := r.PathValue("notification")
+	if _, ok := g.notifications[id]; !ok {
+		writeFailure(w, http.StatusNotFound, "Specified notification not found.")
+		return
+	}
+	delete(g.notifications, id)
+	writeSuccess(w)
+}
+
+// putGraphSubresource routes PUT /v1/users/{user}/graphs/{graph}/{date}/{action}.
+// The path overlaps with updating a notification, so they cannot be registered separately.
+func (s *Server) putGraphSubresource(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("date") == "notifications" {
+		r.SetPathValue("notification", r.PathValue("action"))
+		s.updateNotification(w, r)
+		return
+	}
+
+	switch r.PathValue("action") {
+	case "add":
+		s.addPixel(w, r)
+	case "subtract":
+		s.subtractPixel(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
@@ -0,0 +1,352 @@
+package pixelatest
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "20060102"
+	maxPixelsRange = 365
+)
+
+var (
+	intQuantityPattern   = regexp.MustCompile(`^-?[0-9]+$`)
+	floatQuantityPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+)
+
+type pixel struct {
+	Date         string `json:"date"`
+	Quantity     string `json:"quantity"`
+	OptionalData string `json:"optionalData,omitempty"`
+}
+
+func validDate(date string) bool {
+	_, err := time.Parse(dateLayout, date)
+	return err == nil
+}
+
+func (g *graph) validQuantity(quantity string) bool {
+	if g.definition.Type == graphTypeInt {
+		return intQuantityPattern.MatchString(quantity)
+	}
+	return floatQuantityPattern.MatchString(quantity)
+}
+
+// unit returns the quantity that increment and decrement add.
+func (g *graph) unit() string {
+	if g.definition.Type == graphTypeInt {
+		return "1"
+	}
+	return "0.01"
+}
+
+// sortedPixels returns pixels in date order, limited to [from, to] when they are not empty.
+func (g *graph) sortedPixels(from, to string) []*pixel {
+	pixels := make([]*pixel, 0, len(g.pixels))
+	for _, p := range g.pixels {
+		if (from == "" || p.Date >= from) && (to == "" || p.Date <= to) {
+			pixels = append(pixels, p)
+		}
+	}
+	slices.SortFunc(pixels, func(a, b *pixel) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+	return pixels
+}
+
+func (g *graph) addToPixel(date, quantity string) {
+	p, ok := g.pixels[date]
+	if !ok {
+		p = &pixel{Date: date, Quantity: "0"}
+		g.pixels[date] = p
+	}
+	p.Quantity = addQuantity(g.definition.Type, p.Quantity, quantity)
+}
+
+// datePixel validates the date in the request path.
+func datePixel(w http.ResponseWriter, r *http.Request) (string, bool) {
+	date := r.PathValue("date")
+	if !validDate(date) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check date.")
+		return "", false
+	}
+	return date, true
+}
+
+func (s *Server) createPixel(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	var input pixel
+	if !decodeBody(w, r, &input) {
+		return
+	}
+	if !validDate(input.Date) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check date.")
+		return
+	}
+	if !g.validQuantity(input.Quantity) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check quantity.")
+		return
+	}
+
+	g.pixels[input.Date] = &input
+	writeSuccess(w)
+}
+
+func (s *Server) getPixels(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	query := r.URL.Query()
+	from, to, ok := pixelsRange(query.Get("from"), query.Get("to"), s.now().In(g.location()))
+	if !ok {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check from and to.")
+		return
+	}
+
+	pixels := g.sortedPixels(from, to)
+	if query.Get("withBody") == "true" {
+		writeJSON(w, http.StatusOK, map[string]any{"pixels": pixels})
+		return
+	}
+
+	dates := make([]string, 0, len(pixels))
+	for _, p := range pixels {
+		dates = append(dates, p.Date)
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"pixels": dates})
+}
+
+// pixelsRange resolves from and to in the way Pixela does.
+// Unspecified ends span 365 days from the specified one, or up to today.
+func pixelsRange(from, to string, today time.Time) (string, string, bool) {
+	var fromDate, toDate time.Time
+	var err error
+	switch {
+	case from == "" && to == "":
+		toDate = today
+		fromDate = toDate.AddDate(0, 0, -maxPixelsRange)
+	case to == "":
+		if fromDate, err = time.Parse(dateLayout, from); err != nil {
+			return "", "", false
+		}
+		toDate = fromDate.AddDate(0, 0, maxPixelsRange)
+	case from == "":
+		if toDate, err = time.Parse(dateLayout, to); err != nil {
+			return "", "", false
+		}
+		fromDate = toDate.AddDate(0, 0, -maxPixelsRange)
+	default:
+		if fromDate, err = time.Parse(dateLayout, from); err != nil {
+			return "", "", false
+		}
+		if toDate, err = time.Parse(dateLayout, to); err != nil {
+			return "", "", false
+		}
+		if toDate.Before(fromDate) || toDate.Sub(fromDate) > maxPixelsRange*24*time.Hour {
+			return "", "", false
+		}
+	}
+	return fromDate.Format(dateLayout), toDate.Format(dateLayout), true
+}
+
+func (s *Server) updatePixels(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	var input []pixel
+	if !decodeBody(w, r, &input) {
+		return
+	}
+	for _, p := range input {
+		if !validDate(p.Date) || !g.validQuantity(p.Quantity) {
+			writeFailure(w, http.StatusBadRequest, "Validation error. Please check date and quantity.")
+			return
+		}
+	}
+
+	for _, p := range input {
+		g.pixels[p.Date] = &p
+	}
+	writeSuccess(w)
+}
+
+func (s *Server) getLatestPixel(w http.ResponseWriter, r *http.Request) {
+	g := s.findGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	pixels := g.sortedPixels("", "")
+	if len(pixels) == 0 {
+		writeFailure(w, http.StatusNotFound, "Specified pixel not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, pixels[len(pixels)-1])
+}
+
+func (s *Server) getTodayPixel(w http.ResponseWriter, r *http.Request) {
+	g := s.findGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	today := g.today(s.now())
+	p, ok := g.pixels[today]
+	if !ok {
+		if r.URL.Query().Get("returnEmpty") != "true" {
+			writeFailure(w, http.StatusNotFound, "Specified pixel not found.")
+			return
+		}
+		p = &pixel{Date: today, Quantity: "0"}
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) getPixel(w http.ResponseWriter, r *http.Request) {
+	g := s.findGraph(w, r)
+	if g == nil {
+		return
+	}
+	date, ok := datePixel(w, r)
+	if !ok {
+		return
+	}
+
+	p, ok := g.pixels[date]
+	if !ok {
+		writeFailure(w, http.StatusNotFound, "Specified pixel not found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"quantity": p.Quantity, "optionalData": p.OptionalData})
+}
+
+func (s *Server) updatePixel(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+	date, ok := datePixel(w, r)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Quantity     *string `json:"quantity"`
+		OptionalData *string `json:"optionalData"`
+	}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+	if input.Quantity != nil && !g.validQuantity(*input.Quantity) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check quantity.")
+		return
+	}
+
+	p, ok := g.pixels[date]
+	if !ok {
+		p = &pixel{Date: date, Quantity: "0"}
+		g.pixels[date] = p
+	}
+	setIfNotNil(&p.Quantity, input.Quantity)
+	setIfNotNil(&p.OptionalData, input.OptionalData)
+	writeSuccess(w)
+}
+
+func (s *Server) deletePixel(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+	date, ok := datePixel(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := g.pixels[date]; !ok {
+		writeFailure(w, http.StatusNotFound, "Specified pixel not found.")
+		return
+	}
+	delete(g.pixels, date)
+	writeSuccess(w)
+}
+
+func (s *Server) incrementPixel(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+	g.addToPixel(g.today(s.now()), g.unit())
+	writeSuccess(w)
+}
+
+func (s *Server) decrementPixel(w http.ResponseWriter, r *http.Request) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+	g.addToPixel(g.today(s.now()), "-"+g.unit())
+	writeSuccess(w)
+}
+
+func (s *Server) addTodayPixel(w http.ResponseWriter, r *http.Request) {
+	s.addQuantityToPixel(w, r, false, false)
+}
+
+func (s *Server) subtractTodayPixel(w http.ResponseWriter, r *http.Request) {
+	s.addQuantityToPixel(w, r, false, true)
+}
+
+func (s *Server) addPixel(w http.ResponseWriter, r *http.Request) {
+	s.addQuantityToPixel(w, r, true, false)
+}
+
+func (s *Server) subtractPixel(w http.ResponseWriter, r *http.Request) {
+	s.addQuantityToPixel(w, r, true, true)
+}
+
+// addQuantityToPixel adds (or subtracts) the quantity in the request body
+// to the pixel of the date in the request path, or of the day when withDate is false.
+func (s *Server) addQuantityToPixel(w http.ResponseWriter, r *http.Request, withDate, subtract bool) {
+	g := s.authenticatedGraph(w, r)
+	if g == nil {
+		return
+	}
+
+	date := g.today(s.now())
+	if withDate {
+		var ok bool
+		if date, ok = datePixel(w, r); !ok {
+			return
+		}
+	}
+
+	var input struct {
+		Quantity string `json:"quantity"`
+	}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+	if !g.validQuantity(input.Quantity) || strings.HasPrefix(input.Quantity, "-") {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check quantity.")
+		return
+	}
+
+	quantity := input.Quantity
+	if subtract {
+		quantity = "-" + quantity
+	}
+	g.addToPixel(date, quantity)
+	writeSuccess(w)
+}
@@ -0,0 +1,237 @@
+// Package pixelatest provides an in-memory fake Pixela server for hermetic testing.
+//
+// The fake server implements the subset of the Pixela API that pixela4go covers:
+// users, user profiles, graphs, pixels, webhooks, channels, notifications and stats.
+// It keeps all state in memory and can simulate request rejection (503 isRejected).
+//
+//	srv := pixelatest.NewServer()
+//	defer srv.Close()
+//
+//	client := pixela.New("username", "password", pixela.WithBaseURL(srv.URL))
+package pixelatest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	// The fake server resolves graph timezones without relying on the host's zoneinfo.
+	_ "time/tzdata"
+)
+
+const userToken = "X-USER-TOKEN"
+
+// A Server is an in-memory fake Pixela server.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	users         map[string]*user
+	webhooks      map[string]*webhook
+	now           func() time.Time
+	rejectNext    int
+	rejectionRate float64
+}
+
+// An Option configures a Server.
+type Option func(s *Server)
+
+// WithClock sets the function that returns the current time (default: time.Now).
+// It decides "today" for increment, decrement, stopwatch, webhooks and stats.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithRejectionRate sets the probability (0.0-1.0) that a request is rejected
+// with 503 and isRejected, as Pixela does for non-supporters.
+func WithRejectionRate(rate float64) Option {
+	return func(s *Server) {
+		s.rejectionRate = rate
+	}
+}
+
+// NewServer starts and returns a new Server.
+// The caller should call Close when finished, to shut it down.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		users:    map[string]*user{},
+		webhooks: map[string]*webhook{},
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// RejectNext makes the next n requests be rejected with 503 and isRejected.
+func (s *Server) RejectNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejectNext = n
+}
+
+// Quantity returns the quantity of the pixel registered on the date (yyyyMMdd).
+func (s *Server) Quantity(userName, graphID, date string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userName]
+	if !ok {
+		return "", false
+	}
+	g, ok := u.graphs[graphID]
+	if !ok {
+		return "", false
+	}
+	p, ok := g.pixels[date]
+	if !ok {
+		return "", false
+	}
+	return p.Quantity, true
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /v1/users", s.createUser)
+	mux.HandleFunc("PUT /v1/users/{user}", s.updateUser)
+	mux.HandleFunc("DELETE /v1/users/{user}", s.deleteUser)
+	mux.HandleFunc("PUT /{profile}", s.updateUserProfile)
+	mux.HandleFunc("GET /{profile}", s.getUserProfile)
+
+	mux.HandleFunc("POST /v1/users/{user}/graphs", s.createGraph)
+	mux.HandleFunc("GET /v1/users/{user}/graphs", s.getGraphs)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}/graph-def", s.getGraph)
+	mux.HandleFunc("PUT /v1/users/{user}/graphs/{graph}", s.updateGraph)
+	mux.HandleFunc("DELETE /v1/users/{user}/graphs/{graph}", s.deleteGraph)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}", s.getGraphSVG)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}/stats", s.getGraphStats)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}/analyze", s.analyzeGraph)
+	mux.HandleFunc("POST /v1/users/{user}/graphs/{graph}/stopwatch", s.stopwatch)
+
+	mux.HandleFunc("POST /v1/users/{user}/graphs/{graph}", s.createPixel)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}/pixels", s.getPixels)
+	mux.HandleFunc("POST /v1/users/{user}/graphs/{graph}/pixels", s.updatePixels)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}/latest", s.getLatestPixel)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}/today", s.getTodayPixel)
+	mux.HandleFunc("PUT /v1/users/{user}/graphs/{graph}/increment", s.incrementPixel)
+	mux.HandleFunc("PUT /v1/users/{user}/graphs/{graph}/decrement", s.decrementPixel)
+	mux.HandleFunc("PUT /v1/users/{user}/graphs/{graph}/add", s.addTodayPixel)
+	mux.HandleFunc("PUT /v1/users/{user}/graphs/{graph}/subtract", s.subtractTodayPixel)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}/{date}", s.getPixel)
+	mux.HandleFunc("PUT /v1/users/{user}/graphs/{graph}/{date}", s.updatePixel)
+	mux.HandleFunc("DELETE /v1/users/{user}/graphs/{graph}/{date}", s.deletePixel)
+	mux.HandleFunc("PUT /v1/users/{user}/graphs/{graph}/{date}/{action}", s.putGraphSubresource)
+
+	mux.HandleFunc("POST /v1/users/{user}/graphs/{graph}/notifications", s.createNotification)
+	mux.HandleFunc("GET /v1/users/{user}/graphs/{graph}/notifications", s.getNotifications)
+	mux.HandleFunc("DELETE /v1/users/{user}/graphs/{graph}/notifications/{notification}", s.deleteNotification)
+
+	mux.HandleFunc("POST /v1/users/{user}/webhooks", s.createWebhook)
+	mux.HandleFunc("GET /v1/users/{user}/webhooks", s.getWebhooks)
+	mux.HandleFunc("POST /v1/users/{user}/webhooks/{hash}", s.invokeWebhook)
+	mux.HandleFunc("DELETE /v1/users/{user}/webhooks/{hash}", s.deleteWebhook)
+
+	mux.HandleFunc("POST /v1/users/{user}/channels", s.createChannel)
+	mux.HandleFunc("GET /v1/users/{user}/channels", s.getChannels)
+	mux.HandleFunc("PUT /v1/users/{user}/channels/{channel}", s.updateChannel)
+	mux.HandleFunc("DELETE /v1/users/{user}/channels/{channel}", s.deleteChannel)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.shouldReject() {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]any{
+				"message":    "Please retry this request. Your request for some APIs will be rejected 25% of the time because you are not a Pixela supporter.",
+				"isSuccess":  false,
+				"isRejected": true,
+			})
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) shouldReject() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rejectNext > 0 {
+		s.rejectNext--
+		return true
+	}
+	return s.rejectionRate > 0 && mathrand.Float64() < s.rejectionRate
+}
+
+// authenticate returns the user named in the request path if X-USER-TOKEN matches.
+// It writes an error response and returns nil otherwise.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) *user {
+	name := r.PathValue("user")
+	u, ok := s.users[name]
+	if !ok || u.token != r.Header.Get(userToken) {
+		writeFailure(w, http.StatusUnauthorized, "User `"+name+"` does not exist or the token is wrong.")
+		return nil
+	}
+	return u
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeSuccess(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]any{"message": "Success.", "isSuccess": true})
+}
+
+func writeFailure(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"message": message, "isSuccess": false})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeFailure(w, http.StatusBadRequest, "Request body is invalid.")
+		return false
+	}
+	return true
+}
+
+func newWebhookHash() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// addQuantity adds b to a exactly, formatting the result for the graph type.
+func addQuantity(graphType, a, b string) string {
+	x, _ := new(big.Rat).SetString(a)
+	y, _ := new(big.Rat).SetString(b)
+	return formatQuantity(graphType, new(big.Rat).Add(x, y))
+}
+
+func formatQuantity(graphType string, r *big.Rat) string {
+	if graphType == graphTypeInt {
+		return r.FloatString(0)
+	}
+
+	s := r.FloatString(10)
+	for s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
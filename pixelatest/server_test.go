@@ -0,0 +1,385 @@
+package pixelatest_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	pixela "github.com/ebc-2in2crc/pixela4go"
+	"github.com/ebc-2in2crc/pixela4go/pixelatest"
+)
+
+const (
+	userName = "pixelatest"
+	token    = "thisissecret"
+	graphID  = "test-graph"
+)
+
+var now = time.Date(2024, 4, 14, 12, 0, 0, 0, time.UTC)
+
+func newServerAndClient(t *testing.T, graphType string, opts ...pixelatest.Option) (*pixelatest.Server, *pixela.Client) {
+	t.Helper()
+
+	opts = append([]pixelatest.Option{pixelatest.WithClock(func() time.Time { return now })}, opts...)
+	srv := pixelatest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	client := pixela.New(userName, token, pixela.WithBaseURL(srv.URL))
+	mustSucceed(t)(client.User().Create(&pixela.UserCreateInput{
+		AgreeTermsOfService: pixela.Bool(true),
+		NotMinor:            pixela.Bool(true),
+	}))
+	mustSucceed(t)(client.Graph().Create(&pixela.GraphCreateInput{
+		ID:       pixela.String(graphID),
+		Name:     pixela.String("graph-name"),
+		Unit:     pixela.String("times"),
+		Type:     pixela.String(graphType),
+		Color:    pixela.String(pixela.GraphColorShibafu),
+		TimeZone: pixela.String("UTC"),
+	}))
+	return srv, client
+}
+
+func mustSucceed(t *testing.T) func(*pixela.Result, error) {
+	t.Helper()
+	return func(result *pixela.Result, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+		if result.IsSuccess == false {
+			t.Fatalf("got: %+v\nwant: success", result)
+		}
+	}
+}
+
+func TestServer_UserTokenMismatch(t *testing.T) {
+	srv, _ := newServerAndClient(t, pixela.GraphTypeInt)
+
+	client := pixela.New(userName, "wrong-token", pixela.WithBaseURL(srv.URL))
+	result, err := client.Graph().Delete(&pixela.GraphDeleteInput{ID: pixela.String(graphID)})
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+	if result.IsSuccess || result.StatusCode != http.StatusUnauthorized {
+		t.Errorf("got: %+v\nwant: %d", result, http.StatusUnauthorized)
+	}
+}
+
+func TestServer_PixelArithmetic(t *testing.T) {
+	srv, client := newServerAndClient(t, pixela.GraphTypeFloat)
+	date := "20240101"
+
+	mustSucceed(t)(client.Pixel().Create(&pixela.PixelCreateInput{
+		GraphID:  pixela.String(graphID),
+		Date:     pixela.String(date),
+		Quantity: pixela.String("0.1"),
+	}))
+	mustSucceed(t)(client.Pixel().Add(&pixela.PixelAddInput{
+		GraphID:  pixela.String(graphID),
+		Date:     pixela.String(date),
+		Quantity: pixela.String("0.2"),
+	}))
+	mustSucceed(t)(client.Pixel().Subtract(&pixela.PixelSubtractInput{
+		GraphID:  pixela.String(graphID),
+		Date:     pixela.String(date),
+		Quantity: pixela.String("0.05"),
+	}))
+	if q, _ := srv.Quantity(userName, graphID, date); q != "0.25" {
+		t.Errorf("got: %s\nwant: 0.25", q)
+	}
+
+	mustSucceed(t)(client.Pixel().Increment(&pixela.PixelIncrementInput{GraphID: pixela.String(graphID)}))
+	mustSucceed(t)(client.Pixel().Increment(&pixela.PixelIncrementInput{GraphID: pixela.String(graphID)}))
+	mustSucceed(t)(client.Pixel().Decrement(&pixela.PixelDecrementInput{GraphID: pixela.String(graphID)}))
+	if q, _ := srv.Quantity(userName, graphID, "20240414"); q != "0.01" {
+		t.Errorf("got: %s\nwant: 0.01", q)
+	}
+}
+
+func TestServer_InvalidQuantity(t *testing.T) {
+	_, client := newServerAndClient(t, pixela.GraphTypeInt)
+
+	result, err := client.Pixel().Create(&pixela.PixelCreateInput{
+		GraphID:  pixela.String(graphID),
+		Date:     pixela.String("20240101"),
+		Quantity: pixela.String("1.5"),
+	})
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+	if result.IsSuccess || result.StatusCode != http.StatusBadRequest {
+		t.Errorf("got: %+v\nwant: %d", result, http.StatusBadRequest)
+	}
+}
+
+func TestServer_GetPixelDates(t *testing.T) {
+	_, client := newServerAndClient(t, pixela.GraphTypeInt)
+	mustSucceed(t)(client.Graph().UpdatePixels(&pixela.GraphUpdatePixelsInput{
+		ID: pixela.String(graphID),
+		Pixels: []pixela.PixelInput{
+			{Date: pixela.String("20220101"), Quantity: pixela.String("1")},
+			{Date: pixela.String("20240102"), Quantity: pixela.String("2"), OptionalData: pixela.String(`{"k":"v"}`)},
+			{Date: pixela.String("20240101"), Quantity: pixela.String("3")},
+		},
+	}))
+
+	pixels, err := client.Graph().GetPixelsWithBody(&pixela.GraphGetPixelDatesInput{
+		ID: pixela.String(graphID),
+	})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := []pixela.PixelWithBody{
+		{Date: "20240101", Quantity: "3"},
+		{Date: "20240102", Quantity: "2", OptionalData: `{"k":"v"}`},
+	}
+	if reflect.DeepEqual(pixels.Pixels, expect) == false {
+		t.Errorf("got: %+v\nwant: %+v", pixels.Pixels, expect)
+	}
+
+	result, err := client.Graph().GetPixelDateList(&pixela.GraphGetPixelDatesInput{
+		ID:   pixela.String(graphID),
+		From: pixela.String("20220101"),
+		To:   pixela.String("20240101"),
+	})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if result.IsSuccess || result.StatusCode != http.StatusBadRequest {
+		t.Errorf("got: %+v\nwant: %d", result, http.StatusBadRequest)
+	}
+}
+
+func TestServer_Stats(t *testing.T) {
+	_, client := newServerAndClient(t, pixela.GraphTypeInt)
+	mustSucceed(t)(client.Graph().UpdatePixels(&pixela.GraphUpdatePixelsInput{
+		ID: pixela.String(graphID),
+		Pixels: []pixela.PixelInput{
+			{Date: pixela.String("20240413"), Quantity: pixela.String("2")},
+			{Date: pixela.String("20240414"), Quantity: pixela.String("4")},
+		},
+	}))
+
+	stats, err := client.Graph().Stats(&pixela.GraphStatsInput{ID: pixela.String(graphID)})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := &pixela.Stats{
+		TotalPixelsCount:  2,
+		MaxQuantity:       "4",
+		MaxDate:           "2024-04-14",
+		MinQuantity:       "2",
+		MinDate:           "2024-04-13",
+		TotalQuantity:     "6",
+		AvgQuantity:       3,
+		TodaysQuantity:    "4",
+		YesterdayQuantity: "2",
+		Result:            pixela.Result{IsSuccess: true, StatusCode: http.StatusOK},
+	}
+	if *stats != *expect {
+		t.Errorf("got: %+v\nwant: %+v", stats, expect)
+	}
+}
+
+func TestServer_StatsFloat(t *testing.T) {
+	_, client := newServerAndClient(t, pixela.GraphTypeFloat)
+	mustSucceed(t)(client.Graph().UpdatePixels(&pixela.GraphUpdatePixelsInput{
+		ID: pixela.String(graphID),
+		Pixels: []pixela.PixelInput{
+			{Date: pixela.String("20240413"), Quantity: pixela.String("0.1")},
+			{Date: pixela.String("20240414"), Quantity: pixela.String("0.2")},
+		},
+	}))
+
+	stats, err := client.Graph().Stats(&pixela.GraphStatsInput{ID: pixela.String(graphID), WithGraphType: true})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := &pixela.Stats{
+		TotalPixelsCount:  2,
+		MaxQuantity:       "0.2",
+		MaxDate:           "2024-04-14",
+		MinQuantity:       "0.1",
+		MinDate:           "2024-04-13",
+		TotalQuantity:     "0.3",
+		AvgQuantity:       0.15,
+		TodaysQuantity:    "0.2",
+		YesterdayQuantity: "0.1",
+		GraphType:         pixela.GraphTypeFloat,
+		Result:            pixela.Result{IsSuccess: true, StatusCode: http.StatusOK},
+	}
+	if *stats != *expect {
+		t.Errorf("got: %+v\nwant: %+v", stats, expect)
+	}
+}
+
+func TestServer_Stopwatch(t *testing.T) {
+	clock := now
+	_, client := newServerAndClient(t, pixela.GraphTypeInt, pixelatest.WithClock(func() time.Time { return clock }))
+	input := &pixela.GraphStopwatchInput{ID: pixela.String(graphID)}
+
+	mustSucceed(t)(client.Graph().Stopwatch(input))
+	clock = clock.Add(90 * time.Minute)
+	mustSucceed(t)(client.Graph().Stopwatch(input))
+
+	pixel, err := client.Graph().GetToday(&pixela.GraphGetTodayInput{ID: pixela.String(graphID)})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if pixel.Quantity != "90" {
+		t.Errorf("got: %s\nwant: 90", pixel.Quantity)
+	}
+}
+
+func TestServer_Webhook(t *testing.T) {
+	srv, client := newServerAndClient(t, pixela.GraphTypeInt)
+
+	created, err := client.Webhook().Create(&pixela.WebhookCreateInput{
+		GraphID: pixela.String(graphID),
+		Type:    pixela.String(pixela.WebhookTypeIncrement),
+	})
+	if err != nil || created.IsSuccess == false {
+		t.Fatalf("got: %+v, %v\nwant: success", created, err)
+	}
+
+	mustSucceed(t)(client.Webhook().Invoke(&pixela.WebhookInvokeInput{WebhookHash: pixela.String(created.WebhookHash)}))
+	if q, _ := srv.Quantity(userName, graphID, "20240414"); q != "1" {
+		t.Errorf("got: %s\nwant: 1", q)
+	}
+
+	mustSucceed(t)(client.Graph().Delete(&pixela.GraphDeleteInput{ID: pixela.String(graphID)}))
+	definitions, err := client.Webhook().GetAll()
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	if len(definitions.Webhooks) != 0 {
+		t.Errorf("got: %+v\nwant: []", definitions.Webhooks)
+	}
+}
+
+func TestServer_Channel(t *testing.T) {
+	_, client := newServerAndClient(t, pixela.GraphTypeInt)
+	detail := &pixela.SlackDetail{
+		URL:         pixela.String("https://hooks.slack.com/services/xxxx"),
+		UserName:    pixela.String("slack-user"),
+		ChannelName: pixela.String("slack-channel"),
+	}
+
+	mustSucceed(t)(client.Channel().Create(&pixela.ChannelCreateInput{
+		ID:     pixela.String("my-channel"),
+		Name:   pixela.String("channel-name"),
+		Type:   pixela.String(pixela.ChannelTypeSlack),
+		Detail: detail,
+	}))
+	mustSucceed(t)(client.Channel().Update(&pixela.ChannelUpdateInput{
+		ID:     pixela.String("my-channel"),
+		Name:   pixela.String("new-channel-name"),
+		Type:   pixela.String(pixela.ChannelTypeSlack),
+		Detail: detail,
+	}))
+
+	definitions, err := client.Channel().GetAll()
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := []pixela.ChannelDefinition{
+		{
+			ID:   "my-channel",
+			Name: "new-channel-name",
+			Type: pixela.ChannelTypeSlack,
+			Detail: pixela.SlackDetailOutput{
+				URL:         "https://hooks.slack.com/services/xxxx",
+				UserName:    "slack-user",
+				ChannelName: "slack-channel",
+			},
+		},
+	}
+	if reflect.DeepEqual(definitions.Channels, expect) == false {
+		t.Errorf("got: %+v\nwant: %+v", definitions.Channels, expect)
+	}
+
+	mustSucceed(t)(client.Channel().Delete(&pixela.ChannelDeleteInput{ID: pixela.String("my-channel")}))
+	result, err := client.Channel().Delete(&pixela.ChannelDeleteInput{ID: pixela.String("my-channel")})
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+	if result.StatusCode != http.StatusNotFound {
+		t.Errorf("got: %d\nwant: %d", result.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestServer_Notification(t *testing.T) {
+	_, client := newServerAndClient(t, pixela.GraphTypeInt)
+	mustSucceed(t)(client.Channel().Create(&pixela.ChannelCreateInput{
+		ID:   pixela.String("my-channel"),
+		Name: pixela.String("channel-name"),
+		Type: pixela.String(pixela.ChannelTypeSlack),
+		Detail: &pixela.SlackDetail{
+			URL:         pixela.String("https://hooks.slack.com/services/xxxx"),
+			UserName:    pixela.String("slack-user"),
+			ChannelName: pixela.String("slack-channel"),
+		},
+	}))
+
+	input := &pixela.NotificationCreateInput{
+		GraphID:   pixela.String(graphID),
+		ID:        pixela.String("my-notification"),
+		Name:      pixela.String("notification-name"),
+		Target:    pixela.String(pixela.NotificationTargetQuantity),
+		Condition: pixela.String(pixela.NotificationConditionGreaterThan),
+		Threshold: pixela.String("5"),
+		ChannelID: pixela.String("unknown-channel"),
+	}
+	result, err := client.Notification().Create(input)
+	if err != nil || result.StatusCode != http.StatusBadRequest {
+		t.Errorf("got: %+v, %v\nwant: %d", result, err, http.StatusBadRequest)
+	}
+
+	input.ChannelID = pixela.String("my-channel")
+	mustSucceed(t)(client.Notification().Create(input))
+	mustSucceed(t)(client.Notification().Update(&pixela.NotificationUpdateInput{
+		GraphID:   pixela.String(graphID),
+		ID:        pixela.String("my-notification"),
+		Condition: pixela.String(pixela.NotificationConditionMultipleOf),
+		RemindBy:  pixela.String("21"),
+	}))
+
+	definitions, err := client.Notification().GetAll(&pixela.NotificationGetAllInput{GraphID: pixela.String(graphID)})
+	if err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+	expect := []pixela.NotificationDefinition{
+		{
+			ID:        "my-notification",
+			Name:      "notification-name",
+			Target:    pixela.NotificationTargetQuantity,
+			Condition: pixela.NotificationConditionMultipleOf,
+			Threshold: "5",
+			RemindBy:  "21",
+			ChannelID: "my-channel",
+		},
+	}
+	if reflect.DeepEqual(definitions.Notifications, expect) == false {
+		t.Errorf("got: %+v\nwant: %+v", definitions.Notifications, expect)
+	}
+
+	mustSucceed(t)(client.Notification().Delete(&pixela.NotificationDeleteInput{
+		GraphID: pixela.String(graphID),
+		ID:      pixela.String("my-notification"),
+	}))
+}
+
+func TestServer_RejectNext(t *testing.T) {
+	srv, client := newServerAndClient(t, pixela.GraphTypeInt)
+
+	srv.RejectNext(1)
+	_, err := client.Graph().Delete(&pixela.GraphDeleteInput{ID: pixela.String(graphID)})
+	if errors.Is(err, pixela.ErrAPICallRejected) == false {
+		t.Errorf("got: %v\nwant: %v", err, pixela.ErrAPICallRejected)
+	}
+
+	mustSucceed(t)(client.Graph().Delete(&pixela.GraphDeleteInput{ID: pixela.String(graphID)}))
+}
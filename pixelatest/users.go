@@ -0,0 +1,182 @@
+package pixelatest
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var (
+	userNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{1,32}$`)
+	tokenPattern    = regexp.MustCompile(`^[ -~]{8,128}$`)
+)
+
+type user struct {
+	name     string
+	token    string
+	profile  profile
+	graphs   map[string]*graph
+	channels map[string]*channel
+}
+
+type profile struct {
+	DisplayName       *string  `json:"displayName"`
+	GravatarIconEmail *string  `json:"gravatarIconEmail"`
+	Title             *string  `json:"title"`
+	Timezone          *string  `json:"timezone"`
+	AboutURL          *string  `json:"aboutURL"`
+	ContributeURLs    []string `json:"contributeURLs"`
+	PinnedGraphID     *string  `json:"pinnedGraphID"`
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Token               string `json:"token"`
+		UserName            string `json:"username"`
+		AgreeTermsOfService string `json:"agreeTermsOfService"`
+		NotMinor            string `json:"notMinor"`
+	}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+
+	if input.AgreeTermsOfService != "yes" || input.NotMinor != "yes" {
+		writeFailure(w, http.StatusBadRequest, "agreeTermsOfService and notMinor must be yes.")
+		return
+	}
+	if !userNamePattern.MatchString(input.UserName) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check username.")
+		return
+	}
+	if !tokenPattern.MatchString(input.Token) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check token.")
+		return
+	}
+	if _, ok := s.users[input.UserName]; ok {
+		writeFailure(w, http.StatusConflict, "This user already exist.")
+		return
+	}
+
+	s.users[input.UserName] = &user{
+		name:     input.UserName,
+		token:    input.Token,
+		graphs:   map[string]*graph{},
+		channels: map[string]*channel{},
+	}
+	writeSuccess(w)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	var input struct {
+		NewToken string `json:"newToken"`
+	}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+	if !tokenPattern.MatchString(input.NewToken) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check newToken.")
+		return
+	}
+
+	u.token = input.NewToken
+	writeSuccess(w)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	for hash, wh := range s.webhooks {
+		if wh.userName == u.name {
+			delete(s.webhooks, hash)
+		}
+	}
+	delete(s.users, u.name)
+	writeSuccess(w)
+}
+
+// profileUser returns the user of a "/@username" request path.
+func (s *Server) profileUser(w http.ResponseWriter, r *http.Request) *user {
+	name, ok := strings.CutPrefix(r.PathValue("profile"), "@")
+	if !ok {
+		http.NotFound(w, r)
+		return nil
+	}
+	u, ok := s.users[name]
+	if !ok {
+		writeFailure(w, http.StatusNotFound, "Specified user not found.")
+		return nil
+	}
+	return u
+}
+
+func (s *Server) updateUserProfile(w http.ResponseWriter, r *http.Request) {
+	u := s.profileUser(w, r)
+	if u == nil {
+		return
+	}
+	if u.token != r.Header.Get(userToken) {
+		writeFailure(w, http.StatusUnauthorized, "User `"+u.name+"` does not exist or the token is wrong.")
+		return
+	}
+
+	var input profile
+	if !decodeBody(w, r, &input) {
+		return
+	}
+	if input.Timezone != nil && !validTimezone(*input.Timezone) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check timezone.")
+		return
+	}
+
+	mergeProfile(&u.profile, &input)
+	writeSuccess(w)
+}
+
+func mergeProfile(dst, src *profile) {
+	if src.DisplayName != nil {
+		dst.DisplayName = src.DisplayName
+	}
+	if src.GravatarIconEmail != nil {
+		dst.GravatarIconEmail = src.GravatarIconEmail
+	}
+	if src.Title != nil {
+		dst.Title = src.Title
+	}
+	if src.Timezone != nil {
+		dst.Timezone = src.Timezone
+	}
+	if src.AboutURL != nil {
+		dst.AboutURL = src.AboutURL
+	}
+	if src.ContributeURLs != nil {
+		dst.ContributeURLs = src.ContributeURLs
+	}
+	if src.PinnedGraphID != nil {
+		dst.PinnedGraphID = src.PinnedGraphID
+	}
+}
+
+func (s *Server) getUserProfile(w http.ResponseWriter, r *http.Request) {
+	u := s.profileUser(w, r)
+	if u == nil {
+		return
+	}
+
+	name := u.name
+	if u.profile.DisplayName != nil {
+		name = *u.profile.DisplayName
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<!DOCTYPE html><html><head><title>%s</title></head><body><h1>%s</h1></body></html>",
+		html.EscapeString(name), html.EscapeString(name))
+}
@@ -0,0 +1,119 @@
+package pixelatest
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var webhookTypes = []string{"increment", "decrement", "add", "subtract", "stopwatch"}
+
+type webhook struct {
+	WebhookHash string `json:"webhookHash"`
+	GraphID     string `json:"graphID"`
+	Type        string `json:"type"`
+	Quantity    string `json:"quantity,omitempty"`
+	userName    string
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	var input struct {
+		GraphID  string `json:"graphID"`
+		Type     string `json:"type"`
+		Quantity string `json:"quantity"`
+	}
+	if !decodeBody(w, r, &input) {
+		return
+	}
+	g, ok := u.graphs[input.GraphID]
+	if !ok {
+		writeFailure(w, http.StatusNotFound, "Specified graph not found.")
+		return
+	}
+	if !slices.Contains(webhookTypes, input.Type) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check type.")
+		return
+	}
+	if (input.Type == "add" || input.Type == "subtract") && !g.validQuantity(input.Quantity) {
+		writeFailure(w, http.StatusBadRequest, "Validation error. Please check quantity.")
+		return
+	}
+
+	wh := &webhook{
+		WebhookHash: newWebhookHash(),
+		GraphID:     input.GraphID,
+		Type:        input.Type,
+		Quantity:    input.Quantity,
+		userName:    u.name,
+	}
+	s.webhooks[wh.WebhookHash] = wh
+	writeJSON(w, http.StatusOK, map[string]any{
+		"message":     "Success.",
+		"isSuccess":   true,
+		"webhookHash": wh.WebhookHash,
+	})
+}
+
+func (s *Server) getWebhooks(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	webhooks := []*webhook{}
+	for _, wh := range s.webhooks {
+		if wh.userName == u.name {
+			webhooks = append(webhooks, wh)
+		}
+	}
+	slices.SortFunc(webhooks, func(a, b *webhook) int {
+		return strings.Compare(a.WebhookHash, b.WebhookHash)
+	})
+	writeJSON(w, http.StatusOK, map[string]any{"webhooks": webhooks})
+}
+
+// invokeWebhook applies the webhook to the pixel of the day.
+// Like Pixela, it does not require X-USER-TOKEN.
+func (s *Server) invokeWebhook(w http.ResponseWriter, r *http.Request) {
+	wh, ok := s.webhooks[r.PathValue("hash")]
+	if !ok || wh.userName != r.PathValue("user") {
+		writeFailure(w, http.StatusNotFound, "Specified webhook not found.")
+		return
+	}
+	g := s.users[wh.userName].graphs[wh.GraphID]
+
+	today := g.today(s.now())
+	switch wh.Type {
+	case "increment":
+		g.addToPixel(today, g.unit())
+	case "decrement":
+		g.addToPixel(today, "-"+g.unit())
+	case "add":
+		g.addToPixel(today, wh.Quantity)
+	case "subtract":
+		g.addToPixel(today, "-"+wh.Quantity)
+	case "stopwatch":
+		g.toggleStopwatch(s.now())
+	}
+	writeSuccess(w)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	u := s.authenticate(w, r)
+	if u == nil {
+		return
+	}
+
+	hash := r.PathValue("hash")
+	if wh, ok := s.webhooks[hash]; !ok || wh.userName != u.name {
+		writeFailure(w, http.StatusNotFound, "Specified webhook not found.")
+		return
+	}
+	delete(s.webhooks, hash)
+	writeSuccess(w)
+}
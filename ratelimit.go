@@ -0,0 +1,98 @@
+package pixela
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A RateLimiter limits the rate of API calls with a token bucket.
+// It waits before each attempt of an API call, including retries.
+// A RateLimiter is safe for concurrent use, so it can be shared by Clients.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimiterStats
+}
+
+// RateLimiterStats is statistics of a RateLimiter.
+type RateLimiterStats struct {
+	// Requests is the number of attempts that passed the RateLimiter.
+	Requests int64
+	// ThrottledRequests is the number of attempts that waited for the RateLimiter.
+	ThrottledRequests int64
+	// ThrottledTime is the total time spent waiting for the RateLimiter.
+	ThrottledTime time.Duration
+}
+
+// NewRateLimiter returns a new RateLimiter that allows rate API calls per second
+// with bursts of up to burst API calls. If rate is 0 or less, API calls are not limited.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(max(burst, 1)),
+		tokens: float64(max(burst, 1)),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until an API call is allowed or ctx is done.
+// It returns an error without waiting if ctx would be done before an API call is allowed.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		l.mu.Lock()
+		l.stats.Requests++
+		l.mu.Unlock()
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if deadline, ok := ctx.Deadline(); ok && d > 0 && deadline.Before(now.Add(d)) {
+		l.tokens++
+		l.mu.Unlock()
+		return fmt.Errorf("rate limiter would wait %v beyond the context deadline: %w", d, context.DeadlineExceeded)
+	}
+	l.mu.Unlock()
+
+	if d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			l.mu.Lock()
+			l.tokens++
+			l.stats.ThrottledTime += time.Since(now)
+			l.mu.Unlock()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Requests++
+	if d > 0 {
+		l.stats.ThrottledRequests++
+		l.stats.ThrottledTime += d
+	}
+	return nil
+}
+
+// Stats returns the statistics of the RateLimiter.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
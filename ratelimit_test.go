@@ -0,0 +1,81 @@
+package pixela
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := NewRateLimiter(100, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("got: %v\nwant: >= %v", elapsed, 15*time.Millisecond)
+	}
+	stats := limiter.Stats()
+	if stats.Requests != 4 || stats.ThrottledRequests != 2 {
+		t.Errorf("got: %+v\nwant: 4 requests and 2 throttled requests", stats)
+	}
+	if stats.ThrottledTime <= 0 {
+		t.Errorf("got: %v\nwant: > 0", stats.ThrottledTime)
+	}
+}
+
+func TestRateLimiter_WaitContext(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("got: %v\nwant: nil", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); errors.Is(err, context.DeadlineExceeded) == false {
+		t.Errorf("got: %v\nwant: %v", err, context.DeadlineExceeded)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if err := limiter.Wait(ctx); errors.Is(err, context.Canceled) == false {
+		t.Errorf("got: %v\nwant: %v", err, context.Canceled)
+	}
+	if stats := limiter.Stats(); stats.Requests != 1 {
+		t.Errorf("got: %d\nwant: %d", stats.Requests, 1)
+	}
+}
+
+func TestRateLimiter_Unlimited(t *testing.T) {
+	limiter := NewRateLimiter(0, 1)
+	for i := 0; i < 100; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("got: %v\nwant: nil", err)
+		}
+	}
+	if stats := limiter.Stats(); stats.Requests != 100 || stats.ThrottledRequests != 0 {
+		t.Errorf("got: %+v\nwant: 100 requests", stats)
+	}
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(1000, 10)
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{newRejectedMock(), newOKMock()},
+	}
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Nanosecond}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy), WithRateLimiter(limiter))
+
+	result, err := client.User().Delete()
+	testSuccess(t, result, err)
+	result, err = client.Pixel().Increment(&PixelIncrementInput{GraphID: String(graphID)})
+	testSuccess(t, result, err)
+
+	if stats := limiter.Stats(); stats.Requests != 3 {
+		t.Errorf("got: %d\nwant: %d", stats.Requests, 3)
+	}
+}
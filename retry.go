@@ -4,73 +4,240 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
 	"time"
 )
 
-const maxRetryCount = 20
+const (
+	maxRetryCount    = 20
+	defaultBaseDelay = 100 * time.Millisecond
+)
 
 // RetryCount number of retries when an API call is rejected (max: 20)
+//
+// Deprecated: RetryCount is shared by all clients in a process and is not safe for concurrent use.
+// Use WithRetryPolicy instead. It is used only by clients that have no RetryPolicy.
 var RetryCount = 0
 
 // ErrAPICallRejected API call rejected.
 // See: https://help.pixe.la/en/blog/release-request-rejecting
 var ErrAPICallRejected = errors.New("api call rejected")
 
+// RetryPolicy is the policy to retry API calls.
+// API calls rejected by Pixela (503 with isRejected) or rate limited (429) are always retried while attempts remain.
+// If the response has a Retry-After header, or rate-limit headers reporting no remaining requests,
+// the retry waits for the time given by the headers instead of the backoff delay, capped by MaxDelay.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first call (max: 21).
+	// If it is 0 or 1, API calls are not retried.
+	MaxAttempts int
+	// BaseDelay is the delay of exponential backoff (default: 100ms).
+	// The n-th retry waits BaseDelay * 2^(n-1), and the first retry is not delayed.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts. If it is 0, the delay is not capped.
+	MaxDelay time.Duration
+	// Jitter randomly shortens each delay by up to this fraction (0.0-1.0).
+	Jitter float64
+	// RetryableStatusCodes are additional response status codes to retry.
+	RetryableStatusCodes []int
+	// RetryOnError reports whether an API call that failed with err (e.g. a network error) should be retried.
+	// If it is nil, only API calls with a safe method (GET, HEAD and OPTIONS) that failed with
+	// a transient network error such as a timeout or a connection reset are retried.
+	RetryOnError func(err error) bool
+	// MaxTotalDelay caps the total time waiting between attempts.
+	// If the next wait would exceed it, the API call is not retried any more. If it is 0, the total is not capped.
+	MaxTotalDelay time.Duration
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	if p.MaxAttempts > maxRetryCount+1 {
+		return maxRetryCount + 1
+	}
+	return p.MaxAttempts
+}
+
+// delay returns the wait time before the next attempt after the attempt-th (0-based) attempt failed.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	if attempt == 0 {
+		return 0
+	}
+
+	base := p.BaseDelay
+	if base <= 0 {
+		base = defaultBaseDelay
+	}
+	d := time.Duration(math.Pow(2, float64(attempt)) * float64(base))
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * math.Min(p.Jitter, 1) * float64(d))
+	}
+	return d
+}
+
+// legacyRetryPolicy returns the RetryPolicy for clients that have no RetryPolicy.
+func legacyRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: getRetryCount() + 1}
+}
+
 type retryer struct {
-	processFunc func(r *retryer)
-	maxRetry    int
+	processFunc func(ctx context.Context, r *retryer)
+	policy      *RetryPolicy
+	method      string
 	statusCode  int
+	header      http.Header
 	body        []byte
 	err         error
+	rejected    bool
+	attempts    int
+	// startAttempt is called before each attempt with the 0-based attempt number.
+	// It returns the context of the attempt and the function called after the attempt
+	// with whether the request will be retried.
+	startAttempt func(ctx context.Context, attempt int) (context.Context, func(retryable bool))
 }
 
 func (m *retryer) do(ctx context.Context) error {
-	for i := 0; i <= m.maxRetry; i++ {
-		m.process()
-		if !m.shouldRetry() {
+	attempts := m.policy.maxAttempts()
+	var waited time.Duration
+	for i := 0; i < attempts; i++ {
+		attemptCtx, end := ctx, func(bool) {}
+		if m.startAttempt != nil {
+			attemptCtx, end = m.startAttempt(ctx, i)
+		}
+		m.attempts++
+		m.process(attemptCtx)
+		retryable := m.shouldRetry()
+		end(retryable && i < attempts-1)
+		if !retryable {
 			return m.err
 		}
+		if i == attempts-1 {
+			break
+		}
+
+		d := m.delay(i)
+		if m.policy.MaxTotalDelay > 0 && waited+d > m.policy.MaxTotalDelay {
+			break
+		}
+		waited += d
 
-		waitTime := m.getWaitTimeExp(i, 100)
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-time.After(time.Millisecond * time.Duration(waitTime)):
+		case <-time.After(d):
 		}
 	}
 
-	return ErrAPICallRejected
+	if m.rejected {
+		return ErrAPICallRejected
+	}
+	return m.err
 }
 
-func (m *retryer) process() {
-	m.processFunc(m)
+func (m *retryer) process(ctx context.Context) {
+	m.processFunc(ctx, m)
+}
+
+// delay returns the wait time before the next attempt after the attempt-th (0-based) attempt failed.
+func (m *retryer) delay(attempt int) time.Duration {
+	if d, ok := retryAfter(m.header, time.Now()); ok {
+		if m.policy.MaxDelay > 0 && d > m.policy.MaxDelay {
+			d = m.policy.MaxDelay
+		}
+		return d
+	}
+	return m.policy.delay(attempt)
 }
 
 func (m *retryer) shouldRetry() bool {
+	m.rejected = false
 	if m.err != nil {
-		return false
+		if m.policy.RetryOnError != nil {
+			return m.policy.RetryOnError(m.err)
+		}
+		return isSafeMethod(m.method) && isTransientError(m.err)
 	}
-	if m.statusCode != http.StatusServiceUnavailable {
-		return false
+
+	if m.statusCode == http.StatusTooManyRequests {
+		return true
 	}
 
-	r, err := parseNormalResponse(m.body)
-	if err != nil {
-		m.err = fmt.Errorf("failed to parse normal response: %w", err)
-		return false
+	if m.statusCode == http.StatusServiceUnavailable {
+		r, err := parseNormalResponse(m.body)
+		if err == nil && r.IsRejected {
+			m.rejected = true
+			return true
+		}
+		if err != nil && !slices.Contains(m.policy.RetryableStatusCodes, m.statusCode) {
+			m.err = fmt.Errorf("failed to parse normal response: %w", err)
+			return false
+		}
 	}
 
-	return r.IsRejected
+	return slices.Contains(m.policy.RetryableStatusCodes, m.statusCode)
 }
 
-func (m *retryer) getWaitTimeExp(retryCount int, baseDelayMilliSeconds int) (delayMilliSeconds int) {
-	if retryCount == 0 {
-		return 0
+// unixTimeThreshold tells a Unix time from seconds in rate-limit reset headers.
+const unixTimeThreshold = 365 * 24 * 60 * 60
+
+// retryAfter returns the wait time given by the Retry-After header, or by the rate-limit headers
+// (RateLimit-Reset or X-RateLimit-Reset) when no requests remain.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	if v := header.Get("Retry-After"); v != "" {
+		if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+			return time.Duration(sec) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
 	}
 
-	return int(math.Pow(2, float64(retryCount)) * float64(baseDelayMilliSeconds))
+	for _, prefix := range []string{"", "X-"} {
+		if header.Get(prefix+"RateLimit-Remaining") != "0" {
+			continue
+		}
+		reset, err := strconv.ParseInt(header.Get(prefix+"RateLimit-Reset"), 10, 64)
+		if err != nil || reset < 0 {
+			continue
+		}
+		if reset > unixTimeThreshold {
+			return max(time.Unix(reset, 0).Sub(now), 0), true
+		}
+		return time.Duration(reset) * time.Second, true
+	}
+	return 0, false
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// isTransientError reports whether err is a network error that may succeed on retry.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
 }
 
 func getRetryCount() int {
@@ -0,0 +1,314 @@
+package pixela
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type httpClientSequenceMock struct {
+	responses []*httpClientMock
+	headers   []http.Header
+	errs      []error
+	calls     int
+}
+
+func (c *httpClientSequenceMock) Do(req *http.Request) (*http.Response, error) {
+	i := min(c.calls, len(c.responses)-1)
+	c.calls++
+	if c.errs != nil && c.errs[i] != nil {
+		return nil, c.errs[i]
+	}
+	header := http.Header{}
+	if c.headers != nil && c.headers[i] != nil {
+		header = c.headers[i]
+	}
+	return &http.Response{
+		StatusCode: c.responses[i].statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(c.responses[i].body)),
+	}, nil
+}
+
+func newTooManyRequestsMock() *httpClientMock {
+	return &httpClientMock{
+		statusCode: http.StatusTooManyRequests,
+		body:       []byte(`{"message":"Too many requests.","isSuccess":false}`),
+	}
+}
+
+func newRejectedMock() *httpClientMock {
+	return &httpClientMock{
+		statusCode: http.StatusServiceUnavailable,
+		body:       []byte(`{"message":"Please retry this request.","isSuccess":false,"isRejected":true}`),
+	}
+}
+
+func TestRetryPolicy_MaxAttempts(t *testing.T) {
+	params := []struct {
+		maxAttempts int
+		expect      int
+	}{
+		{maxAttempts: -1, expect: 1},
+		{maxAttempts: 0, expect: 1},
+		{maxAttempts: 5, expect: 5},
+		{maxAttempts: 100, expect: maxRetryCount + 1},
+	}
+
+	for _, p := range params {
+		policy := &RetryPolicy{MaxAttempts: p.maxAttempts}
+		if policy.maxAttempts() != p.expect {
+			t.Errorf("got: %d\nwant: %d", policy.maxAttempts(), p.expect)
+		}
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := &RetryPolicy{}
+	params := []struct {
+		attempt int
+		expect  time.Duration
+	}{
+		{attempt: 0, expect: 0},
+		{attempt: 1, expect: 200 * time.Millisecond},
+		{attempt: 3, expect: 800 * time.Millisecond},
+	}
+	for _, p := range params {
+		if d := policy.delay(p.attempt); d != p.expect {
+			t.Errorf("got: %v\nwant: %v", d, p.expect)
+		}
+	}
+
+	policy = &RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+	if d := policy.delay(5); d != 3*time.Second {
+		t.Errorf("got: %v\nwant: %v", d, 3*time.Second)
+	}
+
+	policy = &RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if d := policy.delay(1); d < time.Second || d > 2*time.Second {
+			t.Errorf("got: %v\nwant: between %v and %v", d, time.Second, 2*time.Second)
+		}
+	}
+}
+
+func TestRetry_Rejected(t *testing.T) {
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{newRejectedMock(), newRejectedMock(), newOKMock()},
+	}
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Nanosecond}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+	if mock.calls != 3 {
+		t.Errorf("got: %d\nwant: %d", mock.calls, 3)
+	}
+}
+
+func TestRetry_RejectedExhausted(t *testing.T) {
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{newRejectedMock(), newRejectedMock(), newOKMock()},
+	}
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Nanosecond}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy))
+	_, err := client.User().Delete()
+
+	if errors.Is(err, ErrAPICallRejected) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrAPICallRejected)
+	}
+	if mock.calls != 2 {
+		t.Errorf("got: %d\nwant: %d", mock.calls, 2)
+	}
+}
+
+func TestRetry_RetryableStatusCodes(t *testing.T) {
+	internalServerError := &httpClientMock{
+		statusCode: http.StatusInternalServerError,
+		body:       []byte(`{"message":"failed.","isSuccess":false}`),
+	}
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{internalServerError, newOKMock()},
+	}
+	policy := RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{http.StatusInternalServerError}}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+
+	mock = &httpClientSequenceMock{
+		responses: []*httpClientMock{internalServerError, newOKMock()},
+	}
+	client = New(userName, token, WithHTTPClient(mock), WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+	result, err = client.User().Delete()
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+	if result.StatusCode != http.StatusInternalServerError {
+		t.Errorf("got: %d\nwant: %d", result.StatusCode, http.StatusInternalServerError)
+	}
+}
+
+func TestRetry_RetryOnError(t *testing.T) {
+	errNetwork := errors.New("connection reset by peer")
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{nil, newOKMock()},
+		errs:      []error{errNetwork, nil},
+	}
+	policy := RetryPolicy{
+		MaxAttempts:  2,
+		RetryOnError: func(err error) bool { return errors.Is(err, errNetwork) },
+	}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+
+	mock.calls = 0
+	client = New(userName, token, WithHTTPClient(mock), WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+	_, err = client.User().Delete()
+	if errors.Is(err, errNetwork) == false {
+		t.Errorf("got: %v\nwant: %v", err, errNetwork)
+	}
+}
+
+func TestRetry_LegacyRetryCount(t *testing.T) {
+	retryCount := RetryCount
+	RetryCount = 1
+	t.Cleanup(func() { RetryCount = retryCount })
+
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{newRejectedMock(), newOKMock()},
+	}
+	client := New(userName, token, WithHTTPClient(mock))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 4, 14, 12, 0, 0, 0, time.UTC)
+	params := []struct {
+		header http.Header
+		expect time.Duration
+		ok     bool
+	}{
+		{header: http.Header{}, expect: 0, ok: false},
+		{header: http.Header{"Retry-After": {"3"}}, expect: 3 * time.Second, ok: true},
+		{header: http.Header{"Retry-After": {"Sun, 14 Apr 2024 12:00:05 GMT"}}, expect: 5 * time.Second, ok: true},
+		{header: http.Header{"Retry-After": {"Sun, 14 Apr 2024 11:00:00 GMT"}}, expect: 0, ok: true},
+		{header: http.Header{"Retry-After": {"invalid"}}, expect: 0, ok: false},
+		{header: http.Header{"Ratelimit-Remaining": {"0"}, "Ratelimit-Reset": {"7"}}, expect: 7 * time.Second, ok: true},
+		{header: http.Header{"Ratelimit-Remaining": {"1"}, "Ratelimit-Reset": {"7"}}, expect: 0, ok: false},
+		{header: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1713096010"}}, expect: 10 * time.Second, ok: true},
+	}
+
+	for _, p := range params {
+		d, ok := retryAfter(p.header, now)
+		if d != p.expect || ok != p.ok {
+			t.Errorf("%v: got: %v, %v\nwant: %v, %v", p.header, d, ok, p.expect, p.ok)
+		}
+	}
+}
+
+func TestRetry_TooManyRequests(t *testing.T) {
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{newTooManyRequestsMock(), newOKMock()},
+		headers:   []http.Header{{"Retry-After": {"0"}}, nil},
+	}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+	if mock.calls != 2 {
+		t.Errorf("got: %d\nwant: %d", mock.calls, 2)
+	}
+}
+
+func TestRetry_MaxTotalDelay(t *testing.T) {
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{newTooManyRequestsMock(), newOKMock()},
+		headers:   []http.Header{{"Retry-After": {"60"}}, nil},
+	}
+	policy := RetryPolicy{MaxAttempts: 2, MaxTotalDelay: time.Second}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy))
+	result, err := client.User().Delete()
+
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+	if result.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("got: %d\nwant: %d", result.StatusCode, http.StatusTooManyRequests)
+	}
+	if mock.calls != 1 {
+		t.Errorf("got: %d\nwant: %d", mock.calls, 1)
+	}
+}
+
+func TestRetry_MaxDelayCapsRetryAfter(t *testing.T) {
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{newTooManyRequestsMock(), newOKMock()},
+		headers:   []http.Header{{"Retry-After": {"86400"}}, nil},
+	}
+	policy := RetryPolicy{MaxAttempts: 2, MaxDelay: time.Millisecond}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy))
+
+	done := make(chan struct{})
+	var (
+		result *Result
+		err    error
+	)
+	go func() {
+		result, err = client.User().Delete()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("got: waiting for Retry-After\nwant: the delay capped by MaxDelay")
+	}
+
+	testSuccess(t, result, err)
+	if mock.calls != 2 {
+		t.Errorf("got: %d\nwant: %d", mock.calls, 2)
+	}
+}
+
+func TestRetry_TransientError(t *testing.T) {
+	errTimeout := &net.OpError{Op: "read", Err: &timeoutError{}}
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{nil, {statusCode: http.StatusOK, body: []byte(`{"graphs":[]}`)}},
+		errs:      []error{errTimeout, nil},
+	}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+	_, err := client.Graph().GetAll()
+	if err != nil {
+		t.Errorf("got: %v\nwant: nil", err)
+	}
+	if mock.calls != 2 {
+		t.Errorf("got: %d\nwant: %d", mock.calls, 2)
+	}
+
+	mock = &httpClientSequenceMock{
+		responses: []*httpClientMock{nil, newOKMock()},
+		errs:      []error{io.ErrUnexpectedEOF, nil},
+	}
+	client = New(userName, token, WithHTTPClient(mock), WithRetryPolicy(RetryPolicy{MaxAttempts: 2}))
+	_, err = client.User().Delete()
+	if errors.Is(err, io.ErrUnexpectedEOF) == false {
+		t.Errorf("got: %v\nwant: %v", err, io.ErrUnexpectedEOF)
+	}
+	if mock.calls != 1 {
+		t.Errorf("got: %d\nwant: %d", mock.calls, 1)
+	}
+}
+
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "i/o timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
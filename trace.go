@@ -0,0 +1,63 @@
+package pixela
+
+import "context"
+
+// A Tracer traces API calls, e.g. with spans and metrics of OpenTelemetry.
+// An operation is a call of a sub-client method such as Graph.Stats,
+// and it makes one or more attempts when it is retried.
+// The pixelaotel package provides a Tracer for OpenTelemetry.
+type Tracer interface {
+	// StartOperation is called when an operation starts.
+	// It returns the context of the operation and the function called when the operation ends.
+	StartOperation(ctx context.Context, op Operation) (context.Context, func(OperationResult))
+	// StartAttempt is called before each attempt of an operation with the context of the operation.
+	// It returns the context of the HTTP request and the function called when the attempt ends.
+	StartAttempt(ctx context.Context, attempt Attempt) (context.Context, func(AttemptResult))
+}
+
+// Operation is an API operation traced by a Tracer.
+type Operation struct {
+	// Name is the name of the operation such as "pixela.Graph.Stats".
+	Name string
+	// Method is the HTTP method.
+	Method string
+	// URL is the request URL. Webhook hashes are redacted.
+	URL string
+}
+
+// OperationResult is the result of an operation.
+type OperationResult struct {
+	// StatusCode is the status code of the last attempt. It is 0 if no response was received.
+	StatusCode int
+	// Attempts is the number of attempts.
+	Attempts int
+	// Err is the error of the API call, such as an *APIError.
+	// It is nil for failure responses unless WithErrorOnFailure is specified, so check StatusCode as well.
+	Err error
+}
+
+// Attempt is an attempt of an operation.
+type Attempt struct {
+	Operation
+	// Number is the attempt number starting at 1.
+	Number int
+}
+
+// AttemptResult is the result of an attempt.
+type AttemptResult struct {
+	// StatusCode is the status code of the response. It is 0 if no response was received.
+	StatusCode int
+	// IsRejected reports whether Pixela rejected the request.
+	IsRejected bool
+	// Err is the error of the attempt such as a network error.
+	Err error
+	// Retry reports whether the operation will be retried.
+	Retry bool
+}
+
+// WithTracer makes the client trace each API operation and its attempts with tracer.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
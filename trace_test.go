@@ -0,0 +1,119 @@
+package pixela
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type tracerContextKey struct{}
+
+type tracerMock struct {
+	operations []Operation
+	results    []OperationResult
+	attempts   []Attempt
+	attempted  []AttemptResult
+}
+
+func (t *tracerMock) StartOperation(ctx context.Context, op Operation) (context.Context, func(OperationResult)) {
+	t.operations = append(t.operations, op)
+	return context.WithValue(ctx, tracerContextKey{}, op.Name), func(result OperationResult) {
+		t.results = append(t.results, result)
+	}
+}
+
+func (t *tracerMock) StartAttempt(ctx context.Context, attempt Attempt) (context.Context, func(AttemptResult)) {
+	t.attempts = append(t.attempts, attempt)
+	if ctx.Value(tracerContextKey{}) != attempt.Name {
+		panic("the context of the attempt is not derived from the context of the operation")
+	}
+	return context.WithValue(ctx, tracerContextKey{}, attempt.Number), func(result AttemptResult) {
+		t.attempted = append(t.attempted, result)
+	}
+}
+
+func TestTracer(t *testing.T) {
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{newRejectedMock(), newOKMock()},
+	}
+	var seen []any
+	record := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			seen = append(seen, req.Context().Value(tracerContextKey{}))
+			return next.Do(req)
+		})
+	}
+	tracer := &tracerMock{}
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Nanosecond}
+	client := New(userName, token, WithHTTPClient(mock), WithRetryPolicy(policy), WithMiddleware(record), WithTracer(tracer))
+	result, err := client.User().Delete()
+
+	testSuccess(t, result, err)
+	expectOperation := Operation{Name: "pixela.User.Delete", Method: http.MethodDelete, URL: APIBaseURLForV1 + "/users/" + userName}
+	if !reflect.DeepEqual(tracer.operations, []Operation{expectOperation}) {
+		t.Errorf("got: %v\nwant: %v", tracer.operations, []Operation{expectOperation})
+	}
+	expectResults := []OperationResult{{StatusCode: http.StatusOK, Attempts: 2}}
+	if !reflect.DeepEqual(tracer.results, expectResults) {
+		t.Errorf("got: %v\nwant: %v", tracer.results, expectResults)
+	}
+	expectAttempts := []Attempt{{Operation: expectOperation, Number: 1}, {Operation: expectOperation, Number: 2}}
+	if !reflect.DeepEqual(tracer.attempts, expectAttempts) {
+		t.Errorf("got: %v\nwant: %v", tracer.attempts, expectAttempts)
+	}
+	expectAttempted := []AttemptResult{
+		{StatusCode: http.StatusServiceUnavailable, IsRejected: true, Retry: true},
+		{StatusCode: http.StatusOK},
+	}
+	if !reflect.DeepEqual(tracer.attempted, expectAttempted) {
+		t.Errorf("got: %v\nwant: %v", tracer.attempted, expectAttempted)
+	}
+	if !reflect.DeepEqual(seen, []any{1, 2}) {
+		t.Errorf("got: %v\nwant: %v", seen, []any{1, 2})
+	}
+}
+
+func TestTracer_ErrorOnFailure(t *testing.T) {
+	mock := &httpClientSequenceMock{
+		responses: []*httpClientMock{{
+			statusCode: http.StatusNotFound,
+			body:       []byte(`{"message":"Specified graph not found.","isSuccess":false}`),
+		}},
+	}
+	tracer := &tracerMock{}
+	client := New(userName, token, WithHTTPClient(mock), WithErrorOnFailure(), WithTracer(tracer))
+	client.Graph().Stats(&GraphStatsInput{ID: String(graphID)})
+
+	if len(tracer.results) != 1 {
+		t.Fatalf("got: %d\nwant: %d", len(tracer.results), 1)
+	}
+	if tracer.operations[0].Name != "pixela.Graph.Stats" {
+		t.Errorf("got: %s\nwant: %s", tracer.operations[0].Name, "pixela.Graph.Stats")
+	}
+	if errors.Is(tracer.results[0].Err, ErrGraphNotFound) == false {
+		t.Errorf("got: %v\nwant: %v", tracer.results[0].Err, ErrGraphNotFound)
+	}
+	if tracer.results[0].StatusCode != http.StatusNotFound {
+		t.Errorf("got: %d\nwant: %d", tracer.results[0].StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestTracer_PixelDates(t *testing.T) {
+	g := &Graph{UserName: userName, Token: token, baseURL: APIBaseURL}
+	params := []struct {
+		withBody *bool
+		expect   string
+	}{
+		{withBody: nil, expect: "pixela.Graph.GetPixelDateList"},
+		{withBody: Bool(true), expect: "pixela.Graph.GetPixelsWithBody"},
+	}
+	for _, p := range params {
+		param := g.createGetPixelDatesRequestParameter(&GraphGetPixelDatesInput{ID: String(graphID), WithBody: p.withBody})
+		if param.Operation != p.expect {
+			t.Errorf("got: %s\nwant: %s", param.Operation, p.expect)
+		}
+	}
+}
@@ -5,14 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-
 )
 
 // A User manages communication with the Pixela user API.
 type User struct {
-	UserName   string
-	Token      string
-	httpClient HTTPClient
+	UserName  string
+	Token     string
+	requester *requester
+	baseURL   string
 }
 
 // Create creates a new Pixela user.
@@ -22,12 +22,15 @@ func (u *User) Create(input *UserCreateInput) (*Result, error) {
 
 // CreateWithContext creates a new Pixela user.
 func (u *User) CreateWithContext(ctx context.Context, input *UserCreateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := u.createCreateRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create user create parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, u.httpClient, param)
+	return doRequestAndParseResponse(ctx, u.requester, param)
 }
 
 // UserCreateInput is input of User.Create().
@@ -39,6 +42,14 @@ type UserCreateInput struct {
 	ThanksCode *string
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *UserCreateInput) Validate() error {
+	v := newValidator("UserCreateInput")
+	v.requiredBool("AgreeTermsOfService", in.AgreeTermsOfService)
+	v.requiredBool("NotMinor", in.NotMinor)
+	return v.err()
+}
+
 func (u *User) createCreateRequestParameter(input *UserCreateInput) (*requestParameter, error) {
 	b, err := json.Marshal(struct {
 		Token               string `json:"token"`
@@ -58,10 +69,11 @@ func (u *User) createCreateRequestParameter(input *UserCreateInput) (*requestPar
 	}
 
 	return &requestParameter{
-		Method: http.MethodPost,
-		URL:    APIBaseURLForV1 + "/users",
-		Header: map[string]string{},
-		Body:   b,
+		Operation: "pixela.User.Create",
+		Method:    http.MethodPost,
+		URL:       u.baseURL + "/v1/users",
+		Header:    map[string]string{},
+		Body:      b,
 	}, nil
 }
 
@@ -79,12 +91,15 @@ func (u *User) Update(input *UserUpdateInput) (*Result, error) {
 
 // UpdateWithContext updates the authentication token for the specified user.
 func (u *User) UpdateWithContext(ctx context.Context, input *UserUpdateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := u.createUpdateRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create user update parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, u.httpClient, param)
+	return doRequestAndParseResponse(ctx, u.requester, param)
 }
 
 // UserUpdateInput is input of User.Update().
@@ -95,6 +110,13 @@ type UserUpdateInput struct {
 	AllowAIProcessing *bool
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *UserUpdateInput) Validate() error {
+	v := newValidator("UserUpdateInput")
+	v.field("NewToken", in.NewToken).required().token()
+	return v.err()
+}
+
 func (u *User) createUpdateRequestParameter(input *UserUpdateInput) (*requestParameter, error) {
 	b, err := json.Marshal(struct {
 		NewToken          string `json:"newToken"`
@@ -110,10 +132,11 @@ func (u *User) createUpdateRequestParameter(input *UserUpdateInput) (*requestPar
 	}
 
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s", u.UserName),
-		Header: map[string]string{userToken: u.Token},
-		Body:   b,
+		Operation: "pixela.User.Update",
+		Method:    http.MethodPut,
+		URL:       u.baseURL + fmt.Sprintf("/v1/users/%s", u.UserName),
+		Header:    map[string]string{userToken: u.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -124,14 +147,15 @@ func (u *User) Delete() (*Result, error) {
 
 // DeleteWithContext deletes the specified registered user.
 func (u *User) DeleteWithContext(ctx context.Context) (*Result, error) {
-	return doRequestAndParseResponse(ctx, u.httpClient, u.createDeleteRequestParameter())
+	return doRequestAndParseResponse(ctx, u.requester, u.createDeleteRequestParameter())
 }
 
 func (u *User) createDeleteRequestParameter() *requestParameter {
 	return &requestParameter{
-		Method: http.MethodDelete,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s", u.UserName),
-		Header: map[string]string{userToken: u.Token},
-		Body:   []byte{},
+		Operation: "pixela.User.Delete",
+		Method:    http.MethodDelete,
+		URL:       u.baseURL + fmt.Sprintf("/v1/users/%s", u.UserName),
+		Header:    map[string]string{userToken: u.Token},
+		Body:      []byte{},
 	}
 }
@@ -5,14 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-
 )
 
 // A UserProfile manages communication with the Pixela user profile API.
 type UserProfile struct {
-	UserName   string
-	Token      string
-	httpClient HTTPClient
+	UserName  string
+	Token     string
+	requester *requester
+	baseURL   string
 }
 
 // Update updates the profile information for the user corresponding to username.
@@ -22,12 +22,15 @@ func (u *UserProfile) Update(input *UserProfileUpdateInput) (*Result, error) {
 
 // UpdateWithContext updates the profile information for the user corresponding to username.
 func (u *UserProfile) UpdateWithContext(ctx context.Context, input *UserProfileUpdateInput) (*Result, error) {
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
 	param, err := u.createUpdateRequestParameter(input)
 	if err != nil {
 		return &Result{}, fmt.Errorf("failed to create user profile update parameter: %w", err)
 	}
 
-	return doRequestAndParseResponse(ctx, u.httpClient, param)
+	return doRequestAndParseResponse(ctx, u.requester, param)
 }
 
 // UserProfileUpdateInput is input of UserProfile.Update().
@@ -41,6 +44,14 @@ type UserProfileUpdateInput struct {
 	PinnedGraphID     *string  `json:"pinnedGraphID,omitempty"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *UserProfileUpdateInput) Validate() error {
+	v := newValidator("UserProfileUpdateInput")
+	v.field("Timezone", in.Timezone).timezone()
+	v.field("PinnedGraphID", in.PinnedGraphID).id()
+	return v.err()
+}
+
 func (u *UserProfile) createUpdateRequestParameter(input *UserProfileUpdateInput) (*requestParameter, error) {
 	b, err := json.Marshal(input)
 	if err != nil {
@@ -48,14 +59,15 @@ func (u *UserProfile) createUpdateRequestParameter(input *UserProfileUpdateInput
 	}
 
 	return &requestParameter{
-		Method: http.MethodPut,
-		URL:    fmt.Sprintf(APIBaseURL+"/@%s", u.UserName),
-		Header: map[string]string{userToken: u.Token},
-		Body:   b,
+		Operation: "pixela.UserProfile.Update",
+		Method:    http.MethodPut,
+		URL:       u.baseURL + fmt.Sprintf("/@%s", u.UserName),
+		Header:    map[string]string{userToken: u.Token},
+		Body:      b,
 	}, nil
 }
 
 // URL outputs the profile of the user specified by username in html format.
 func (u *UserProfile) URL() string {
-	return fmt.Sprintf(APIBaseURL+"/@%s", u.UserName)
+	return u.baseURL + fmt.Sprintf("/@%s", u.UserName)
 }
@@ -50,10 +50,10 @@ func TestUserProfileUpdate(t *testing.T) {
 		DisplayName:       String("displayName"),
 		GravatarIconEmail: String("gravatarIconEmail"),
 		Title:             String("title"),
-		Timezone:          String("timezone"),
+		Timezone:          String("Asia/Tokyo"),
 		AboutURL:          String("aboutURL"),
 		ContributeURLs:    []string{"hoge.com"},
-		PinnedGraphID:     String("pinnedGraphID"),
+		PinnedGraphID:     String("pinned-graph"),
 	}
 	result, err := client.UserProfile().Update(input)
 
@@ -67,10 +67,10 @@ func TestUserProfileUpdateFail(t *testing.T) {
 		DisplayName:       String("displayName"),
 		GravatarIconEmail: String("gravatarIconEmail"),
 		Title:             String("title"),
-		Timezone:          String("timezone"),
+		Timezone:          String("Asia/Tokyo"),
 		AboutURL:          String("aboutURL"),
 		ContributeURLs:    []string{"hoge.com"},
-		PinnedGraphID:     String("pinnedGraphID"),
+		PinnedGraphID:     String("pinned-graph"),
 	}
 	result, err := client.UserProfile().Update(input)
 
@@ -84,10 +84,10 @@ func TestUserProfileUpdateError(t *testing.T) {
 		DisplayName:       String("displayName"),
 		GravatarIconEmail: String("gravatarIconEmail"),
 		Title:             String("title"),
-		Timezone:          String("timezone"),
+		Timezone:          String("Asia/Tokyo"),
 		AboutURL:          String("aboutURL"),
 		ContributeURLs:    []string{"hoge.com"},
-		PinnedGraphID:     String("pinnedGraphID"),
+		PinnedGraphID:     String("pinned-graph"),
 	}
 	_, err := client.UserProfile().Update(input)
 
@@ -1,5 +1,11 @@
 package pixela
 
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
 // String returns a pointer to the string value passed in.
 func String(v string) *string {
 	return &v
@@ -25,3 +31,51 @@ func BoolValue(v *bool) bool {
 	}
 	return *v
 }
+
+// Date returns a pointer to the date of t in yyyyMMdd format.
+// The date is the one in t's location; use t.In() to get the date in the graph's timezone.
+func Date(t time.Time) *string {
+	return String(t.Format(dateLayout))
+}
+
+// IntQuantity returns a pointer to the quantity string of the int value passed in.
+func IntQuantity(v int64) *string {
+	return String(strconv.FormatInt(v, 10))
+}
+
+// FloatQuantity returns a pointer to the quantity string of the float value passed in.
+func FloatQuantity(v float64) *string {
+	return String(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+func parseDate(date string, loc *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(dateLayout, date, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse date: %w", err)
+	}
+	return t, nil
+}
+
+func parseIntQuantity(quantity string) (int64, error) {
+	v, err := strconv.ParseInt(quantity, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse quantity as int: %w", err)
+	}
+	return v, nil
+}
+
+func parseFloatQuantity(quantity string) (float64, error) {
+	v, err := strconv.ParseFloat(quantity, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse quantity as float: %w", err)
+	}
+	return v, nil
+}
+
+// nonEmpty returns a pointer to s, or nil if s is empty so that the field is omitted.
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -1,6 +1,9 @@
 package pixela
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestString(t *testing.T) {
 	s := "Hello Pixela!"
@@ -49,3 +52,40 @@ func TestBoolValue(t *testing.T) {
 		}
 	}
 }
+
+func TestDate(t *testing.T) {
+	d := time.Date(2024, 4, 14, 23, 30, 0, 0, time.UTC)
+
+	if StringValue(Date(d)) != "20240414" {
+		t.Errorf("got: %v\nwant: %v", StringValue(Date(d)), "20240414")
+	}
+
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	if StringValue(Date(d.In(tokyo))) != "20240415" {
+		t.Errorf("got: %v\nwant: %v", StringValue(Date(d.In(tokyo))), "20240415")
+	}
+}
+
+func TestIntQuantity(t *testing.T) {
+	if StringValue(IntQuantity(-12)) != "-12" {
+		t.Errorf("got: %v\nwant: %v", StringValue(IntQuantity(-12)), "-12")
+	}
+}
+
+func TestFloatQuantity(t *testing.T) {
+	params := []struct {
+		v      float64
+		expect string
+	}{
+		{v: 1.5, expect: "1.5"},
+		{v: 0.01, expect: "0.01"},
+		{v: 2, expect: "2"},
+		{v: 1e21, expect: "1000000000000000000000"},
+	}
+
+	for _, p := range params {
+		if StringValue(FloatQuantity(p.v)) != p.expect {
+			t.Errorf("got: %v\nwant: %v", StringValue(FloatQuantity(p.v)), p.expect)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package pixela
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidInput is matched by a ValidationError.
+var ErrInvalidInput = errors.New("invalid input")
+
+// ValidationError is an invalid input found before sending the request.
+// It lists all the problems of the input.
+//
+// Quantities of inputs are checked to be numbers, but not against the graph type, which inputs do not know.
+// Operations that know the graph type, such as BulkWriter with GraphType, Graph.Copy and Client.Restore,
+// check it. Otherwise use ValidateQuantity.
+type ValidationError struct {
+	// Input is the name of the input type such as "GraphCreateInput".
+	Input string
+	// Problems are the problems of the fields.
+	Problems []FieldProblem
+}
+
+// FieldProblem is a problem of a field of an input.
+type FieldProblem struct {
+	// Field is the name of the field such as "ID" or "Pixels[0].Date".
+	Field string
+	// Message describes the problem such as "is required".
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		problems[i] = p.Field + " " + p.Message
+	}
+	input := e.Input
+	if input == "" {
+		input = "input"
+	}
+	return fmt.Sprintf("invalid %s: %s", input, strings.Join(problems, "; "))
+}
+
+// Is reports whether target is ErrInvalidInput.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+var (
+	idPattern       = regexp.MustCompile(`^[a-z][a-z0-9-]{1,16}$`)
+	tokenPattern    = regexp.MustCompile(`^[ -~]{8,128}$`)
+	intPattern      = regexp.MustCompile(`^-?[0-9]+$`)
+	floatPattern    = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+	graphTypes      = []string{GraphTypeInt, GraphTypeFloat}
+	graphColors     = []string{GraphColorShibafu, GraphColorMomiji, GraphColorSora, GraphColorIchou, GraphColorAjisai, GraphColorKuro}
+	selfSufficients = []string{GraphSelfSufficientIncrement, GraphSelfSufficientDecrement, GraphSelfSufficientNone}
+	webhookTypes    = []string{WebhookTypeIncrement, WebhookTypeDecrement, WebhookTypeStopwatch, WebhookTypeAdd, WebhookTypeSubtract}
+
+	notificationConditions = []string{
+		NotificationConditionGreaterThan,
+		NotificationConditionEqual,
+		NotificationConditionLessThan,
+		NotificationConditionMultipleOf,
+	}
+)
+
+// ValidateQuantity reports whether quantity is valid for the graph type (GraphTypeInt or GraphTypeFloat).
+func ValidateQuantity(graphType, quantity string) error {
+	v := newValidator("")
+	v.field("Quantity", &quantity).quantity(graphType)
+	return v.err()
+}
+
+// tzDatabaseAvailable reports whether the time zone database is available.
+// Time zones are not validated without it, not to reject valid names.
+var tzDatabaseAvailable = sync.OnceValue(func() bool {
+	_, err := time.LoadLocation("Asia/Tokyo")
+	return err == nil
+})
+
+// validator collects the problems of the fields of an input.
+type validator struct {
+	input    string
+	problems []FieldProblem
+}
+
+func newValidator(input string) *validator {
+	return &validator{input: input}
+}
+
+func (v *validator) add(field, format string, a ...any) {
+	v.problems = append(v.problems, FieldProblem{Field: field, Message: fmt.Sprintf(format, a...)})
+}
+
+// err returns a *ValidationError with the problems, or nil if there is no problem.
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Input: v.input, Problems: v.problems}
+}
+
+// field returns the checker of the string field. The checks of an unspecified or empty field are skipped,
+// and the checks after the first failed one are skipped so that a field has at most one problem.
+func (v *validator) field(name string, value *string) *fieldChecker {
+	return &fieldChecker{v: v, name: name, value: value, done: value == nil || *value == ""}
+}
+
+// requiredBool checks that the bool field is specified.
+func (v *validator) requiredBool(name string, value *bool) {
+	if value == nil {
+		v.add(name, "is required")
+	}
+}
+
+type fieldChecker struct {
+	v     *validator
+	name  string
+	value *string
+	done  bool
+}
+
+// check adds the problem if the value is not valid.
+func (c *fieldChecker) check(valid func(string) bool, format string, a ...any) *fieldChecker {
+	if c.done || valid(*c.value) {
+		return c
+	}
+	c.v.add(c.name, format, a...)
+	c.done = true
+	return c
+}
+
+func (c *fieldChecker) required() *fieldChecker {
+	if c.value == nil || *c.value == "" {
+		c.v.add(c.name, "is required")
+		c.done = true
+	}
+	return c
+}
+
+// id checks the pattern of IDs of graphs, channels and notifications.
+func (c *fieldChecker) id() *fieldChecker {
+	return c.check(idPattern.MatchString, "must match %s", idPattern)
+}
+
+func (c *fieldChecker) token() *fieldChecker {
+	return c.check(tokenPattern.MatchString, "must be 8 to 128 printable ASCII characters")
+}
+
+func (c *fieldChecker) date() *fieldChecker {
+	return c.check(isDate, "must be a date in yyyyMMdd format")
+}
+
+// quantity checks that the field is a quantity of the graph type, or of either type if graphType is "".
+func (c *fieldChecker) quantity(graphType string) *fieldChecker {
+	switch graphType {
+	case GraphTypeInt:
+		return c.check(intPattern.MatchString, "must be an integer")
+	case GraphTypeFloat:
+		return c.check(floatPattern.MatchString, "must be a decimal number")
+	}
+	return c.check(floatPattern.MatchString, "must be a number")
+}
+
+func (c *fieldChecker) oneOf(values ...string) *fieldChecker {
+	return c.check(func(s string) bool { return slices.Contains(values, s) }, "must be one of %s", strings.Join(values, ", "))
+}
+
+func (c *fieldChecker) timezone() *fieldChecker {
+	return c.check(isTimezone, "must be a time zone name such as Asia/Tokyo")
+}
+
+func (c *fieldChecker) httpsURL() *fieldChecker {
+	return c.check(isHTTPSURL, "must be an https URL")
+}
+
+func (c *fieldChecker) hour() *fieldChecker {
+	return c.check(isHour, "must be an hour from 0 to 23")
+}
+
+func isDate(s string) bool {
+	_, err := parseDate(s, time.UTC)
+	return err == nil
+}
+
+func isTimezone(s string) bool {
+	if !tzDatabaseAvailable() {
+		return true
+	}
+	_, err := time.LoadLocation(s)
+	return err == nil && s != "" && s != "Local"
+}
+
+func isHTTPSURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+func isHour(s string) bool {
+	h, err := strconv.Atoi(s)
+	return err == nil && h >= 0 && h <= 23
+}
@@ -0,0 +1,181 @@
+package pixela
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	input := &GraphCreateInput{
+		ID:             String("1-invalid"),
+		Unit:           String("times"),
+		Type:           String("double"),
+		Color:          String(GraphColorShibafu),
+		TimeZone:       String("Asia/Nowhere"),
+		SelfSufficient: String(""),
+	}
+	err := input.Validate()
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) == false {
+		t.Fatalf("got: %v\nwant: *ValidationError", err)
+	}
+	expect := &ValidationError{
+		Input: "GraphCreateInput",
+		Problems: []FieldProblem{
+			{Field: "ID", Message: "must match ^[a-z][a-z0-9-]{1,16}$"},
+			{Field: "Name", Message: "is required"},
+			{Field: "Type", Message: "must be one of int, float"},
+			{Field: "TimeZone", Message: "must be a time zone name such as Asia/Tokyo"},
+		},
+	}
+	if !reflect.DeepEqual(validationErr, expect) {
+		t.Errorf("got: %v\nwant: %v", validationErr, expect)
+	}
+	if errors.Is(err, ErrInvalidInput) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrInvalidInput)
+	}
+	expectMessage := "invalid GraphCreateInput: ID must match ^[a-z][a-z0-9-]{1,16}$; Name is required; " +
+		"Type must be one of int, float; TimeZone must be a time zone name such as Asia/Tokyo"
+	if err.Error() != expectMessage {
+		t.Errorf("got: %s\nwant: %s", err.Error(), expectMessage)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	params := []struct {
+		name   string
+		input  interface{ Validate() error }
+		expect []FieldProblem
+	}{
+		{
+			name:  "valid pixel",
+			input: &PixelCreateInput{GraphID: String(graphID), Date: String("20180915"), Quantity: String("-1.5")},
+		},
+		{
+			name:  "invalid pixel",
+			input: &PixelCreateInput{GraphID: String(graphID), Date: String("20180931"), Quantity: String("1e3")},
+			expect: []FieldProblem{
+				{Field: "Date", Message: "must be a date in yyyyMMdd format"},
+				{Field: "Quantity", Message: "must be a number"},
+			},
+		},
+		{
+			name:   "missing graph ID",
+			input:  &GraphStatsInput{},
+			expect: []FieldProblem{{Field: "ID", Message: "is required"}},
+		},
+		{
+			name: "invalid pixels",
+			input: &GraphUpdatePixelsInput{
+				ID:     String(graphID),
+				Pixels: []PixelInput{{Date: String("20180915"), Quantity: String("1")}, {Date: String("2018-09-15")}},
+			},
+			expect: []FieldProblem{
+				{Field: "Pixels[1].Date", Message: "must be a date in yyyyMMdd format"},
+				{Field: "Pixels[1].Quantity", Message: "is required"},
+			},
+		},
+		{
+			name: "too many pixels",
+			input: &GraphUpdatePixelsInput{
+				ID:     String(graphID),
+				Pixels: slices.Repeat([]PixelInput{{Date: String("20180915"), Quantity: String("1")}}, MaxUpdatePixels+1),
+			},
+			expect: []FieldProblem{{Field: "Pixels", Message: "must not have more than 1000 pixels"}},
+		},
+		{
+			name:   "invalid webhook type",
+			input:  &WebhookCreateInput{GraphID: String(graphID), Type: String("multiply")},
+			expect: []FieldProblem{{Field: "Type", Message: "must be one of increment, decrement, stopwatch, add, subtract"}},
+		},
+		{
+			name:   "missing user agreement",
+			input:  &UserCreateInput{NotMinor: Bool(true)},
+			expect: []FieldProblem{{Field: "AgreeTermsOfService", Message: "is required"}},
+		},
+		{
+			name:   "short token",
+			input:  &UserUpdateInput{NewToken: String("short")},
+			expect: []FieldProblem{{Field: "NewToken", Message: "must be 8 to 128 printable ASCII characters"}},
+		},
+		{
+			name:  "invalid slack detail",
+			input: &ChannelCreateInput{ID: String("channel-id"), Name: String("name"), Type: String(ChannelTypeSlack), Detail: &SlackDetail{URL: String("http://example.com")}},
+			expect: []FieldProblem{
+				{Field: "Detail.URL", Message: "must be an https URL"},
+				{Field: "Detail.UserName", Message: "is required"},
+				{Field: "Detail.ChannelName", Message: "is required"},
+			},
+		},
+		{
+			name:   "invalid remind hour",
+			input:  &NotificationUpdateInput{GraphID: String(graphID), ID: String("notification-id"), RemindBy: String("24")},
+			expect: []FieldProblem{{Field: "RemindBy", Message: "must be an hour from 0 to 23"}},
+		},
+		{
+			name:   "empty optional field",
+			input:  &UserProfileUpdateInput{PinnedGraphID: String("")},
+			expect: nil,
+		},
+	}
+
+	for _, p := range params {
+		err := p.input.Validate()
+		if p.expect == nil {
+			if err != nil {
+				t.Errorf("%s: got: %v\nwant: nil", p.name, err)
+			}
+			continue
+		}
+
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) == false {
+			t.Errorf("%s: got: %v\nwant: *ValidationError", p.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(validationErr.Problems, p.expect) {
+			t.Errorf("%s: got: %v\nwant: %v", p.name, validationErr.Problems, p.expect)
+		}
+	}
+}
+
+func TestValidateQuantity(t *testing.T) {
+	params := []struct {
+		graphType string
+		quantity  string
+		valid     bool
+	}{
+		{graphType: GraphTypeInt, quantity: "5", valid: true},
+		{graphType: GraphTypeInt, quantity: "-5", valid: true},
+		{graphType: GraphTypeInt, quantity: "5.5", valid: false},
+		{graphType: GraphTypeFloat, quantity: "5.5", valid: true},
+		{graphType: GraphTypeFloat, quantity: "5", valid: true},
+		{graphType: GraphTypeFloat, quantity: "NaN", valid: false},
+		{graphType: "", quantity: "five", valid: false},
+	}
+	for _, p := range params {
+		err := ValidateQuantity(p.graphType, p.quantity)
+		if (err == nil) != p.valid {
+			t.Errorf("%s %s: got: %v\nwant valid: %v", p.graphType, p.quantity, err, p.valid)
+		}
+	}
+}
+
+func TestValidate_BeforeRequest(t *testing.T) {
+	mock := &httpClientSequenceMock{responses: []*httpClientMock{newOKMock()}}
+	client := New(userName, token, WithHTTPClient(mock))
+	result, err := client.Pixel().Create(&PixelCreateInput{GraphID: String(graphID), Date: String("today")})
+
+	if errors.Is(err, ErrInvalidInput) == false {
+		t.Errorf("got: %v\nwant: %v", err, ErrInvalidInput)
+	}
+	if *result != (Result{}) {
+		t.Errorf("got: %v\nwant: %v", *result, Result{})
+	}
+	if mock.calls != 0 {
+		t.Errorf("got: %d\nwant: %d", mock.calls, 0)
+	}
+}
@@ -5,14 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-
 )
 
 // A Webhook manages communication with the Pixela webhook API.
 type Webhook struct {
-	UserName   string
-	Token      string
-	httpClient HTTPClient
+	UserName  string
+	Token     string
+	requester *requester
+	baseURL   string
 }
 
 // Create create a new Webhook.
@@ -22,12 +22,15 @@ func (w *Webhook) Create(input *WebhookCreateInput) (*WebhookCreateResult, error
 
 // CreateWithContext create a new Webhook.
 func (w *Webhook) CreateWithContext(ctx context.Context, input *WebhookCreateInput) (*WebhookCreateResult, error) {
+	if err := input.Validate(); err != nil {
+		return &WebhookCreateResult{}, err
+	}
 	param, err := w.createCreateRequestParameter(input)
 	if err != nil {
 		return &WebhookCreateResult{}, fmt.Errorf("failed to create webhook create parameter: %w", err)
 	}
 
-	b, status, err := doRequest(ctx, w.httpClient, param)
+	b, status, err := doRequest(ctx, w.requester, param)
 	if err != nil {
 		return &WebhookCreateResult{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -49,6 +52,14 @@ type WebhookCreateInput struct {
 	Type *string `json:"type"`
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *WebhookCreateInput) Validate() error {
+	v := newValidator("WebhookCreateInput")
+	v.field("GraphID", in.GraphID).required().id()
+	v.field("Type", in.Type).required().oneOf(webhookTypes...)
+	return v.err()
+}
+
 // Specify the behavior when this Webhook is invoked.
 const (
 	WebhookTypeAdd       = "add"
@@ -71,10 +82,11 @@ func (w *Webhook) createCreateRequestParameter(input *WebhookCreateInput) (*requ
 	}
 
 	return &requestParameter{
-		Method: http.MethodPost,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/webhooks", w.UserName),
-		Header: map[string]string{userToken: w.Token},
-		Body:   b,
+		Operation: "pixela.Webhook.Create",
+		Method:    http.MethodPost,
+		URL:       w.baseURL + fmt.Sprintf("/v1/users/%s/webhooks", w.UserName),
+		Header:    map[string]string{userToken: w.Token},
+		Body:      b,
 	}, nil
 }
 
@@ -85,7 +97,7 @@ func (w *Webhook) GetAll() (*WebhookDefinitions, error) {
 
 // GetAllWithContext get all predefined webhooks definitions.
 func (w *Webhook) GetAllWithContext(ctx context.Context) (*WebhookDefinitions, error) {
-	b, status, err := doRequest(ctx, w.httpClient, w.createGetAllRequestParameter())
+	b, status, err := doRequest(ctx, w.requester, w.createGetAllRequestParameter())
 	if err != nil {
 		return &WebhookDefinitions{}, fmt.Errorf("failed to do request: %w", err)
 	}
@@ -115,10 +127,11 @@ type WebhookDefinition struct {
 
 func (w *Webhook) createGetAllRequestParameter() *requestParameter {
 	return &requestParameter{
-		Method: http.MethodGet,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/webhooks", w.UserName),
-		Header: map[string]string{userToken: w.Token},
-		Body:   []byte{},
+		Operation: "pixela.Webhook.GetAll",
+		Method:    http.MethodGet,
+		URL:       w.baseURL + fmt.Sprintf("/v1/users/%s/webhooks", w.UserName),
+		Header:    map[string]string{userToken: w.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -129,7 +142,10 @@ func (w *Webhook) Delete(input *WebhookDeleteInput) (*Result, error) {
 
 // DeleteWithContext delete the registered Webhook.
 func (w *Webhook) DeleteWithContext(ctx context.Context, input *WebhookDeleteInput) (*Result, error) {
-	return doRequestAndParseResponse(ctx, w.httpClient, w.createDeleteRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, w.requester, w.createDeleteRequestParameter(input))
 }
 
 // WebhookDeleteInput is input of Webhook.Delete().
@@ -138,13 +154,21 @@ type WebhookDeleteInput struct {
 	WebhookHash *string
 }
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *WebhookDeleteInput) Validate() error {
+	v := newValidator("WebhookDeleteInput")
+	v.field("WebhookHash", in.WebhookHash).required()
+	return v.err()
+}
+
 func (w *Webhook) createDeleteRequestParameter(input *WebhookDeleteInput) *requestParameter {
 	hash := StringValue(input.WebhookHash)
 	return &requestParameter{
-		Method: http.MethodDelete,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/webhooks/%s", w.UserName, hash),
-		Header: map[string]string{userToken: w.Token},
-		Body:   []byte{},
+		Operation: "pixela.Webhook.Delete",
+		Method:    http.MethodDelete,
+		URL:       w.baseURL + fmt.Sprintf("/v1/users/%s/webhooks/%s", w.UserName, hash),
+		Header:    map[string]string{userToken: w.Token},
+		Body:      []byte{},
 	}
 }
 
@@ -157,18 +181,29 @@ func (w *Webhook) Invoke(input *WebhookInvokeInput) (*Result, error) {
 // InvokeWithContext invoke the webhook registered in advance.
 // It is used "timezone" setting as post date if Graph's "timezone" is specified, if not specified, calculates it in "UTC".
 func (w *Webhook) InvokeWithContext(ctx context.Context, input *WebhookInvokeInput) (*Result, error) {
-	return doRequestAndParseResponse(ctx, w.httpClient, w.createInvokeRequestParameter(input))
+	if err := input.Validate(); err != nil {
+		return &Result{}, err
+	}
+	return doRequestAndParseResponse(ctx, w.requester, w.createInvokeRequestParameter(input))
 }
 
 // WebhookInvokeInput is input of Webhook.Invoke().
 type WebhookInvokeInput WebhookDeleteInput
 
+// Validate returns a *ValidationError if the input is invalid.
+func (in *WebhookInvokeInput) Validate() error {
+	v := newValidator("WebhookInvokeInput")
+	v.field("WebhookHash", in.WebhookHash).required()
+	return v.err()
+}
+
 func (w *Webhook) createInvokeRequestParameter(input *WebhookInvokeInput) *requestParameter {
 	hash := StringValue(input.WebhookHash)
 	return &requestParameter{
-		Method: http.MethodPost,
-		URL:    fmt.Sprintf(APIBaseURLForV1+"/users/%s/webhooks/%s", w.UserName, hash),
-		Header: map[string]string{contentLength: "0"},
-		Body:   []byte{},
+		Operation: "pixela.Webhook.Invoke",
+		Method:    http.MethodPost,
+		URL:       w.baseURL + fmt.Sprintf("/v1/users/%s/webhooks/%s", w.UserName, hash),
+		Header:    map[string]string{contentLength: "0"},
+		Body:      []byte{},
 	}
 }